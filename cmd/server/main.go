@@ -3,12 +3,37 @@ package main
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/api2spec/api2spec-fixture-gin/internal/router"
 )
 
 func main() {
-	r := router.Setup()
+	// An unset or invalid CAPTURE_REQUESTS leaves request capture disabled
+	captureRequests, _ := strconv.Atoi(os.Getenv("CAPTURE_REQUESTS"))
+	// An unset or invalid BREW_AUTO_COMPLETE_INTERVAL leaves the auto-complete timer off
+	autoCompleteInterval, _ := time.ParseDuration(os.Getenv("BREW_AUTO_COMPLETE_INTERVAL"))
+	// An unset or invalid BREW_COLD_AFTER keeps the default of 30 minutes
+	coldAfter, _ := time.ParseDuration(os.Getenv("BREW_COLD_AFTER"))
+	// An unset or invalid BREW_MAX_FUTURE_SKEW keeps the default of 5 minutes
+	maxFutureSkew, _ := time.ParseDuration(os.Getenv("BREW_MAX_FUTURE_SKEW"))
+	// An unset or invalid REQUIRE_BREW_TEMP keeps the tea's temperature as the default
+	requireBrewTemp, _ := strconv.ParseBool(os.Getenv("REQUIRE_BREW_TEMP"))
+
+	// An unset or invalid RESPONSE_CACHE_TTL leaves response caching off
+	cacheTTL, _ := time.ParseDuration(os.Getenv("RESPONSE_CACHE_TTL"))
+
+	r := router.Setup(router.Options{
+		AdminToken:           os.Getenv("ADMIN_TOKEN"),
+		CaptureRequests:      captureRequests,
+		AutoCompleteInterval: autoCompleteInterval,
+		ColdAfter:            coldAfter,
+		MaxFutureSkew:        maxFutureSkew,
+		RequireBrewTemp:      requireBrewTemp,
+		CacheTTL:             cacheTTL,
+		Locale:               os.Getenv("LOCALE"),
+	})
 
 	port := os.Getenv("PORT")
 	if port == "" {
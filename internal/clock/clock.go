@@ -0,0 +1,44 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time; inject a Fake to control time in tests
+type Clock interface {
+	Now() time.Time
+}
+
+// System is the real wall clock, in UTC
+type System struct{}
+
+// Now returns the current UTC time
+func (System) Now() time.Time {
+	return time.Now().UTC()
+}
+
+// Fake is a manually advanced clock
+type Fake struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+// NewFake creates a fake clock set to now
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now.UTC()}
+}
+
+// Now returns the fake clock's current time
+func (f *Fake) Now() time.Time {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.now
+}
+
+// Advance moves the fake clock forward by d
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/middleware"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/store"
+)
+
+// AdminHandler handles maintenance endpoints
+type AdminHandler struct {
+	store   *store.MemoryStore
+	capture *middleware.RequestCapture
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(store *store.MemoryStore) *AdminHandler {
+	return &AdminHandler{store: store}
+}
+
+// NewAdminHandlerWithCapture creates an admin handler that can report captured requests
+func NewAdminHandlerWithCapture(store *store.MemoryStore, capture *middleware.RequestCapture) *AdminHandler {
+	return &AdminHandler{store: store, capture: capture}
+}
+
+// Repair godoc
+// @Summary Repair denormalized data
+// @Description Rebuild the brew-by-teapot, steep-by-brew and external ID indexes and close gaps in steep numbering
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Success 200 {object} models.RepairReport
+// @Failure 401 {object} models.Error
+// @Router /admin/repair [post]
+func (h *AdminHandler) Repair(c *gin.Context) {
+	c.JSON(http.StatusOK, models.RepairReport{
+		BrewIndexEntriesFixed:       h.store.RebuildBrewIndex(),
+		SteepIndexEntriesFixed:      h.store.RebuildSteepIndex(),
+		ExternalIDIndexEntriesFixed: h.store.RebuildExternalIDIndex(),
+		SteepsRenumbered:            h.store.RenumberSteeps(),
+	})
+}
+
+// RecentRequests godoc
+// @Summary Recently captured requests
+// @Description Get the last captured request/response pairs, newest first, with sensitive headers redacted
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Success 200 {object} models.CapturedRequestListResponse
+// @Failure 401 {object} models.Error
+// @Router /admin/recent-requests [get]
+func (h *AdminHandler) RecentRequests(c *gin.Context) {
+	data := []models.CapturedRequest{}
+	if h.capture != nil {
+		data = h.capture.Recent()
+	}
+
+	c.JSON(http.StatusOK, models.CapturedRequestListResponse{Data: data})
+}
@@ -0,0 +1,126 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/api2spec/api2spec-fixture-gin/internal/handlers"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAdminRouter(t *testing.T, s *store.MemoryStore) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := handlers.NewAdminHandler(s)
+	router.POST("/admin/repair", handler.Repair)
+	return router
+}
+
+func TestAdminHandler_Repair(t *testing.T) {
+	s := store.NewMemoryStore()
+	teapotID := createTestTeapot(t, s)
+	teaID := createTestTea(t, s)
+	brewID := uuid.New().String()
+	s.CreateBrew(models.Brew{
+		ID:               brewID,
+		TeapotID:         teapotID,
+		TeaID:            teaID,
+		Status:           models.BrewSteeping,
+		WaterTempCelsius: 95,
+		StartedAt:        time.Now(),
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	})
+
+	// Corrupt the numbering: a gap after 1 and a duplicate
+	base := time.Now()
+	for i, number := range []int{1, 3, 3, 7} {
+		s.CreateSteep(models.Steep{
+			ID:              uuid.New().String(),
+			BrewID:          brewID,
+			SteepNumber:     number,
+			DurationSeconds: 30,
+			CreatedAt:       base.Add(time.Duration(i) * time.Second),
+		})
+	}
+
+	router := setupAdminRouter(t, s)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/repair", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var report models.RepairReport
+	err := json.Unmarshal(w.Body.Bytes(), &report)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.SteepsRenumbered)
+	assert.Equal(t, 0, report.BrewIndexEntriesFixed)
+
+	steeps := s.ListAllSteepsByBrew(brewID)
+	require.Len(t, steeps, 4)
+	for i, steep := range steeps {
+		assert.Equal(t, i+1, steep.SteepNumber)
+	}
+	assert.True(t, s.TeapotHasBrews(teapotID))
+
+	// A second pass has nothing left to fix
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/repair", nil))
+
+	report = models.RepairReport{}
+	err = json.Unmarshal(w.Body.Bytes(), &report)
+	require.NoError(t, err)
+	assert.Equal(t, models.RepairReport{}, report)
+}
+
+func TestAdminHandler_RepairExternalIDIndex(t *testing.T) {
+	s := store.NewMemoryStore()
+	externalID := "shop-42"
+	original := models.Teapot{
+		ID:         uuid.New().String(),
+		ExternalID: &externalID,
+		Name:       "Original",
+		Material:   models.MaterialCeramic,
+		CapacityMl: 500,
+		Style:      models.StyleEnglish,
+		CreatedAt:  time.Now().Add(-time.Hour),
+	}
+	require.NoError(t, s.CreateTeapot(original))
+
+	// A plain create with the same external ID takes over the index entry,
+	// and deleting it leaves the original unreachable by external ID
+	duplicate := original
+	duplicate.ID = uuid.New().String()
+	duplicate.Name = "Duplicate"
+	duplicate.CreatedAt = time.Now()
+	require.NoError(t, s.CreateTeapot(duplicate))
+	require.True(t, s.DeleteTeapot(duplicate.ID))
+
+	_, found := s.GetTeapotByExternalID(externalID)
+	require.False(t, found)
+
+	router := setupAdminRouter(t, s)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/repair", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var report models.RepairReport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, 1, report.ExternalIDIndexEntriesFixed)
+
+	teapot, found := s.GetTeapotByExternalID(externalID)
+	require.True(t, found)
+	assert.Equal(t, original.ID, teapot.ID)
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+)
+
+// bindingError classifies a ShouldBindJSON or ShouldBindQuery failure: a
+// body that cannot be decoded into the request type is MALFORMED_JSON, with
+// the byte offset in details when known, while input that breaks a binding
+// constraint is VALIDATION_ERROR. Messages use the request's locale.
+func bindingError(c *gin.Context, err error) models.Error {
+	locale := i18n.FromContext(c)
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var validationErrs validator.ValidationErrors
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		return models.Error{
+			Code:    "MALFORMED_JSON",
+			Message: i18n.Sprintf(locale, "Malformed JSON at offset %d: %s", syntaxErr.Offset, syntaxErr.Error()),
+			Details: map[string]string{"offset": strconv.FormatInt(syntaxErr.Offset, 10)},
+		}
+	case errors.As(err, &typeErr):
+		return models.Error{
+			Code:    "MALFORMED_JSON",
+			Message: i18n.Sprintf(locale, "Field %s must be %s, got %s", typeErr.Field, typeErr.Type, typeErr.Value),
+			Details: map[string]string{
+				"field":  typeErr.Field,
+				"offset": strconv.FormatInt(typeErr.Offset, 10),
+			},
+		}
+	case errors.Is(err, io.EOF):
+		return models.Error{
+			Code:    "MALFORMED_JSON",
+			Message: i18n.Translate(locale, "Request body is empty"),
+		}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return models.Error{
+			Code:    "MALFORMED_JSON",
+			Message: i18n.Translate(locale, "Request body ends before the JSON is complete"),
+		}
+	case errors.As(err, &validationErrs):
+		messages := make([]string, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			messages = append(messages, validationMessage(locale, fe))
+		}
+		return models.Error{
+			Code:    "VALIDATION_ERROR",
+			Message: strings.Join(messages, "; "),
+		}
+	}
+
+	return models.Error{
+		Code:    "VALIDATION_ERROR",
+		Message: err.Error(),
+	}
+}
+
+// validationMessage describes one failed binding rule in the given locale
+func validationMessage(locale i18n.Locale, fe validator.FieldError) string {
+	field := fieldName(fe.Field())
+	param := fe.Param()
+
+	var sized string
+	switch fe.Kind() {
+	case reflect.String:
+		sized = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		sized = " items"
+	}
+
+	switch fe.Tag() {
+	case "required", "required_without":
+		return i18n.Sprintf(locale, "%s is required", field)
+	case "min", "gte":
+		return i18n.Sprintf(locale, "%s must be at least %s"+sized, field, param)
+	case "max", "lte":
+		return i18n.Sprintf(locale, "%s must be at most %s"+sized, field, param)
+	case "gt":
+		return i18n.Sprintf(locale, "%s must be greater than %s", field, param)
+	case "lt":
+		return i18n.Sprintf(locale, "%s must be less than %s", field, param)
+	case "oneof":
+		return i18n.Sprintf(locale, "%s must be one of: %s", field, strings.Join(strings.Fields(param), ", "))
+	case "uuid", "anyuuid":
+		return i18n.Sprintf(locale, "%s must be a valid UUID", field)
+	}
+	return i18n.Sprintf(locale, "%s is invalid", field)
+}
+
+// fieldName converts a Go field name to the json/form name the models use,
+// e.g. SteepTempCelsius to steepTempCelsius and TeapotID to teapotId
+func fieldName(goName string) string {
+	if goName == "ID" {
+		return "id"
+	}
+	if base, ok := strings.CutSuffix(goName, "ID"); ok {
+		goName = base + "Id"
+	}
+	return strings.ToLower(goName[:1]) + goName[1:]
+}
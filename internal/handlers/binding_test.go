@@ -0,0 +1,111 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindingErrors_MalformedVersusInvalid(t *testing.T) {
+	s := store.NewMemoryStore()
+	teaRouter := setupTeaRouter(s)
+	teapotRouter := setupTeapotRouter(s)
+	teapotID := createTestTeapot(t, s)
+
+	tests := []struct {
+		name           string
+		router         http.Handler
+		method         string
+		path           string
+		body           string
+		expectedCode   string
+		expectedOffset string
+	}{
+		{
+			name:           "truncated body",
+			router:         teaRouter,
+			method:         http.MethodPost,
+			path:           "/teas",
+			body:           `{"name":"Sencha","type":"gre`,
+			expectedCode:   "MALFORMED_JSON",
+			expectedOffset: "",
+		},
+		{
+			name:           "syntax error",
+			router:         teaRouter,
+			method:         http.MethodPost,
+			path:           "/teas",
+			body:           `{"name":"Sencha",,}`,
+			expectedCode:   "MALFORMED_JSON",
+			expectedOffset: "18",
+		},
+		{
+			name:           "wrong field type",
+			router:         teaRouter,
+			method:         http.MethodPost,
+			path:           "/teas",
+			body:           `{"name":"Sencha","type":"green","steepTempCelsius":"hot","steepTimeSeconds":60}`,
+			expectedCode:   "MALFORMED_JSON",
+			expectedOffset: "56",
+		},
+		{
+			name:         "empty body",
+			router:       teaRouter,
+			method:       http.MethodPost,
+			path:         "/teas",
+			body:         "",
+			expectedCode: "MALFORMED_JSON",
+		},
+		{
+			name:         "constraint violation",
+			router:       teaRouter,
+			method:       http.MethodPost,
+			path:         "/teas",
+			body:         `{"name":"Sencha","type":"green","steepTempCelsius":30,"steepTimeSeconds":60}`,
+			expectedCode: "VALIDATION_ERROR",
+		},
+		{
+			name:         "truncated patch",
+			router:       teapotRouter,
+			method:       http.MethodPatch,
+			path:         "/teapots/" + teapotID,
+			body:         `{"capacityMl":`,
+			expectedCode: "MALFORMED_JSON",
+		},
+		{
+			name:         "patch constraint violation",
+			router:       teapotRouter,
+			method:       http.MethodPatch,
+			path:         "/teapots/" + teapotID,
+			body:         `{"capacityMl":0}`,
+			expectedCode: "VALIDATION_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			tt.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var errResp models.Error
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+			assert.Equal(t, tt.expectedCode, errResp.Code)
+			assert.NotEmpty(t, errResp.Message)
+			if tt.expectedOffset != "" {
+				assert.Equal(t, tt.expectedOffset, errResp.Details["offset"])
+			}
+		})
+	}
+}
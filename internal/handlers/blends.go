@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/store"
+)
+
+// BlendRatioTolerance is how far a blend's component ratios may sum from 1.0
+const BlendRatioTolerance = 0.01
+
+// BlendHandler handles blend-related endpoints
+type BlendHandler struct {
+	store *store.MemoryStore
+}
+
+// NewBlendHandler creates a new blend handler
+func NewBlendHandler(store *store.MemoryStore) *BlendHandler {
+	return &BlendHandler{store: store}
+}
+
+// List godoc
+// @Summary List all blends
+// @Description Get a paginated list of blends
+// @Tags blends
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Success 200 {object} models.BlendListResponse
+// @Failure 400 {object} models.Error
+// @Router /blends [get]
+func (h *BlendHandler) List(c *gin.Context) {
+	var query models.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
+		return
+	}
+
+	// Set defaults
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 20
+	}
+
+	blends, total := h.store.ListBlends(query.Page, query.Limit)
+	totalPages := (total + query.Limit - 1) / query.Limit
+	if totalPages < 0 {
+		totalPages = 0
+	}
+
+	c.JSON(http.StatusOK, models.BlendListResponse{
+		Data: blends,
+		Pagination: models.Pagination{
+			Page:       query.Page,
+			Limit:      query.Limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	})
+}
+
+// Create godoc
+// @Summary Create a blend
+// @Description Create a blend of existing teas whose ratios sum to 1.0
+// @Tags blends
+// @Accept json
+// @Produce json
+// @Param body body models.CreateBlendRequest true "Blend data"
+// @Success 201 {object} models.Blend
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /blends [post]
+func (h *BlendHandler) Create(c *gin.Context) {
+	var req models.CreateBlendRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
+		return
+	}
+
+	components := make([]models.BlendComponent, 0, len(req.Components))
+	seen := make(map[string]bool, len(req.Components))
+	var sum float64
+	for _, component := range req.Components {
+		canonicalizeID(&component.TeaID)
+		if seen[component.TeaID] {
+			c.JSON(http.StatusBadRequest, models.Error{
+				Code:    "VALIDATION_ERROR",
+				Message: i18n.Message(c, "Tea %s appears more than once", component.TeaID),
+			})
+			return
+		}
+		seen[component.TeaID] = true
+
+		if _, found := h.store.GetTea(component.TeaID); !found {
+			c.JSON(http.StatusBadRequest, models.Error{
+				Code:    "VALIDATION_ERROR",
+				Message: i18n.Message(c, "Tea %s not found", component.TeaID),
+			})
+			return
+		}
+
+		sum += component.Ratio
+		components = append(components, models.BlendComponent{
+			TeaID: component.TeaID,
+			Ratio: component.Ratio,
+		})
+	}
+
+	if math.Abs(sum-1) > BlendRatioTolerance {
+		c.JSON(http.StatusBadRequest, models.Error{
+			Code:    "RATIO_SUM_MISMATCH",
+			Message: i18n.Message(c, "Component ratios sum to %.2f; they must sum to 1.0", sum),
+		})
+		return
+	}
+
+	now := time.Now().UTC()
+	blend := models.Blend{
+		ID:         uuid.New().String(),
+		Name:       req.Name,
+		Components: components,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := h.store.CreateBlend(blend); err != nil {
+		respondIDCollision(c)
+		return
+	}
+	c.JSON(http.StatusCreated, blend)
+}
+
+// Get godoc
+// @Summary Get a blend by ID
+// @Description Get a single blend by its UUID
+// @Tags blends
+// @Accept json
+// @Produce json
+// @Param id path string true "Blend ID" format(uuid)
+// @Success 200 {object} models.Blend
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /blends/{id} [get]
+func (h *BlendHandler) Get(c *gin.Context) {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{
+			Code:    "VALIDATION_ERROR",
+			Message: i18n.Message(c, "Invalid blend ID format"),
+		})
+		return
+	}
+
+	blend, found := h.store.GetBlend(id)
+	if !found {
+		c.JSON(http.StatusNotFound, models.Error{
+			Code:    "NOT_FOUND",
+			Message: i18n.Message(c, "Blend not found"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, blend)
+}
@@ -0,0 +1,220 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/api2spec/api2spec-fixture-gin/internal/handlers"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBlendRouter(s *store.MemoryStore) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := handlers.NewBlendHandler(s)
+	router.GET("/blends", handler.List)
+	router.POST("/blends", handler.Create)
+	router.GET("/blends/:id", handler.Get)
+	return router
+}
+
+func TestBlendHandler_Create(t *testing.T) {
+	s := store.NewMemoryStore()
+	assamID := createTestTea(t, s)
+	ceylonID := createTestTea(t, s)
+
+	tests := []struct {
+		name           string
+		body           map[string]any
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "valid blend",
+			body: map[string]any{
+				"name": "Breakfast Blend",
+				"components": []map[string]any{
+					{"teaId": assamID, "ratio": 0.7},
+					{"teaId": ceylonID, "ratio": 0.3},
+				},
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "ratios within tolerance",
+			body: map[string]any{
+				"name": "Thirds",
+				"components": []map[string]any{
+					{"teaId": assamID, "ratio": 0.333},
+					{"teaId": ceylonID, "ratio": 0.666},
+				},
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "ratio sum mismatch",
+			body: map[string]any{
+				"name": "Short Blend",
+				"components": []map[string]any{
+					{"teaId": assamID, "ratio": 0.5},
+					{"teaId": ceylonID, "ratio": 0.3},
+				},
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "RATIO_SUM_MISMATCH",
+		},
+		{
+			name: "component tea not found",
+			body: map[string]any{
+				"name": "Ghost Blend",
+				"components": []map[string]any{
+					{"teaId": assamID, "ratio": 0.5},
+					{"teaId": uuid.New().String(), "ratio": 0.5},
+				},
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name: "duplicate component",
+			body: map[string]any{
+				"name": "Doubled Blend",
+				"components": []map[string]any{
+					{"teaId": assamID, "ratio": 0.5},
+					{"teaId": assamID, "ratio": 0.5},
+				},
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name: "no components",
+			body: map[string]any{
+				"name":       "Empty Blend",
+				"components": []map[string]any{},
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name: "ratio out of range",
+			body: map[string]any{
+				"name": "Negative Blend",
+				"components": []map[string]any{
+					{"teaId": assamID, "ratio": 1.5},
+					{"teaId": ceylonID, "ratio": -0.5},
+				},
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupBlendRouter(s)
+
+			body, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/blends", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusCreated {
+				var errResp models.Error
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+				assert.Equal(t, tt.expectedCode, errResp.Code)
+				return
+			}
+
+			var blend models.Blend
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &blend))
+			assert.NotEmpty(t, blend.ID)
+			assert.Len(t, blend.Components, 2)
+
+			stored, found := s.GetBlend(blend.ID)
+			require.True(t, found)
+			assert.Equal(t, blend.Name, stored.Name)
+		})
+	}
+
+	_, total := s.ListBlends(1, 20)
+	assert.Equal(t, 2, total)
+}
+
+func TestBlendHandler_GetAndList(t *testing.T) {
+	s := store.NewMemoryStore()
+	router := setupBlendRouter(s)
+
+	blendID := uuid.New().String()
+	require.NoError(t, s.CreateBlend(models.Blend{
+		ID:   blendID,
+		Name: "House Blend",
+		Components: []models.BlendComponent{
+			{TeaID: createTestTea(t, s), Ratio: 1},
+		},
+	}))
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{
+			name:           "existing blend",
+			path:           "/blends/" + blendID,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown blend",
+			path:           "/blends/" + uuid.New().String(),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid ID",
+			path:           "/blends/not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				assertErrorResponse(t, w)
+				return
+			}
+
+			var blend models.Blend
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &blend))
+			assert.Equal(t, "House Blend", blend.Name)
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/blends", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.BlendListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, blendID, response.Data[0].ID)
+	assert.Equal(t, 1, response.Pagination.Total)
+}
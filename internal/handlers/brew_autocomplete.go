@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/api2spec/api2spec-fixture-gin/internal/clock"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/store"
+)
+
+// DefaultColdAfter is how long a ready brew waits before going cold
+const DefaultColdAfter = 30 * time.Minute
+
+// AutoCompleteOptions configures the brew auto-complete timer
+type AutoCompleteOptions struct {
+	// Interval is how often brews are scanned
+	Interval time.Duration
+	// ColdAfter is how long after completing a ready brew goes cold; zero uses DefaultColdAfter
+	ColdAfter time.Duration
+	// Clock supplies the current time; nil uses the system clock
+	Clock clock.Clock
+}
+
+// BrewAutoCompleter moves steeping brews to ready once the tea's steep time
+// has elapsed, and ready brews to cold after a further period
+type BrewAutoCompleter struct {
+	store     *store.MemoryStore
+	interval  time.Duration
+	coldAfter time.Duration
+	clock     clock.Clock
+}
+
+// NewBrewAutoCompleter creates an auto-completer; call Run to start it
+func NewBrewAutoCompleter(store *store.MemoryStore, opts AutoCompleteOptions) *BrewAutoCompleter {
+	if opts.ColdAfter <= 0 {
+		opts.ColdAfter = DefaultColdAfter
+	}
+	if opts.Clock == nil {
+		opts.Clock = clock.System{}
+	}
+	return &BrewAutoCompleter{
+		store:     store,
+		interval:  opts.Interval,
+		coldAfter: opts.ColdAfter,
+		clock:     opts.Clock,
+	}
+}
+
+// Run scans brews every interval until ctx is done
+func (a *BrewAutoCompleter) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.Scan()
+		}
+	}
+}
+
+// Scan applies any due transitions and returns how many brews changed
+func (a *BrewAutoCompleter) Scan() int {
+	now := a.clock.Now()
+	changed := 0
+
+	for _, brew := range a.store.ListAllBrews(models.BrewQuery{}) {
+		updated, ok := a.transition(brew, now)
+		// Skip brews modified since they were listed; the next scan sees them again
+		if ok && a.store.CompareAndUpdateBrew(brew, updated) {
+			changed++
+		}
+	}
+	return changed
+}
+
+// transition returns the brew after its due transition, if any
+func (a *BrewAutoCompleter) transition(brew models.Brew, now time.Time) (models.Brew, bool) {
+	switch brew.Status {
+	case models.BrewSteeping:
+		if isPaused(brew) {
+			return brew, false
+		}
+		tea, found := a.store.GetTea(brew.TeaID)
+		if !found {
+			return brew, false
+		}
+		steepTime := time.Duration(tea.SteepTimeSeconds) * time.Second
+		if activeDuration(brew, now) < steepTime {
+			return brew, false
+		}
+		brew.Status = models.BrewReady
+		if brew.CompletedAt == nil {
+			completed := now
+			brew.CompletedAt = &completed
+		}
+
+	case models.BrewReady:
+		readyAt := brew.UpdatedAt
+		if brew.CompletedAt != nil {
+			readyAt = *brew.CompletedAt
+		}
+		if now.Sub(readyAt) < a.coldAfter {
+			return brew, false
+		}
+		brew.Status = models.BrewCold
+
+	default:
+		return brew, false
+	}
+
+	brew.UpdatedAt = now
+	return brew, true
+}
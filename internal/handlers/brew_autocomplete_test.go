@@ -0,0 +1,102 @@
+package handlers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/api2spec/api2spec-fixture-gin/internal/clock"
+	"github.com/api2spec/api2spec-fixture-gin/internal/handlers"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrewAutoCompleter(t *testing.T) {
+	s := store.NewMemoryStore()
+	teapotID := createTestTeapot(t, s)
+	teaID := createTestTea(t, s) // 240s steep time
+	fake := clock.NewFake(time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC))
+
+	newBrew := func(status models.BrewStatus) string {
+		id := uuid.New().String()
+		now := fake.Now()
+		s.CreateBrew(models.Brew{
+			ID:               id,
+			TeapotID:         teapotID,
+			TeaID:            teaID,
+			Status:           status,
+			WaterTempCelsius: 95,
+			StartedAt:        now,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		})
+		return id
+	}
+	steepingID := newBrew(models.BrewSteeping)
+	preparingID := newBrew(models.BrewPreparing)
+
+	status := func(id string) models.BrewStatus {
+		brew, found := s.GetBrew(id)
+		require.True(t, found)
+		return brew.Status
+	}
+
+	completer := handlers.NewBrewAutoCompleter(s, handlers.AutoCompleteOptions{
+		Interval:  5 * time.Millisecond,
+		ColdAfter: 10 * time.Minute,
+		Clock:     fake,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go completer.Run(ctx)
+
+	// Nothing is due before the steep time elapses
+	fake.Advance(200 * time.Second)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, models.BrewSteeping, status(steepingID))
+
+	fake.Advance(40 * time.Second)
+	assert.Eventually(t, func() bool {
+		return status(steepingID) == models.BrewReady
+	}, time.Second, 5*time.Millisecond)
+
+	brew, _ := s.GetBrew(steepingID)
+	require.NotNil(t, brew.CompletedAt)
+	assert.True(t, brew.CompletedAt.Equal(fake.Now()))
+
+	fake.Advance(10 * time.Minute)
+	assert.Eventually(t, func() bool {
+		return status(steepingID) == models.BrewCold
+	}, time.Second, 5*time.Millisecond)
+
+	// Brews that never started steeping are left alone
+	assert.Equal(t, models.BrewPreparing, status(preparingID))
+}
+
+func TestBrewAutoCompleter_SkipsPausedBrews(t *testing.T) {
+	s := store.NewMemoryStore()
+	fake := clock.NewFake(time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC))
+	start := fake.Now()
+	brewID := uuid.New().String()
+	s.CreateBrew(models.Brew{
+		ID:               brewID,
+		TeapotID:         createTestTeapot(t, s),
+		TeaID:            createTestTea(t, s),
+		Status:           models.BrewSteeping,
+		WaterTempCelsius: 95,
+		StartedAt:        start,
+		Pauses:           []models.BrewPause{{PausedAt: start.Add(time.Minute)}},
+		CreatedAt:        start,
+		UpdatedAt:        start,
+	})
+
+	completer := handlers.NewBrewAutoCompleter(s, handlers.AutoCompleteOptions{Clock: fake})
+	fake.Advance(time.Hour)
+
+	assert.Equal(t, 0, completer.Scan())
+	brew, _ := s.GetBrew(brewID)
+	assert.Equal(t, models.BrewSteeping, brew.Status)
+}
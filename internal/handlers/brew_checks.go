@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+)
+
+// Temperature deviations (°C) from the tea's recommendation that raise issues
+const (
+	tempWarningDelta = 5
+	tempErrorDelta   = 15
+)
+
+// Teapot capacity bounds (ml) used by the advisory checks
+const (
+	minBrewCapacityMl   = 100
+	maxGongfuCapacityMl = 500
+)
+
+// gongfuTeaTypes are usually brewed in small vessels with many short steeps
+var gongfuTeaTypes = map[models.TeaType]bool{
+	models.TeaOolong: true,
+	models.TeaPuerh:  true,
+}
+
+// brewIssue is a validation issue tagged with its severity
+type brewIssue struct {
+	models.BrewValidationIssue
+	isError bool
+}
+
+// Validate godoc
+// @Summary Validate a brew before creating it
+// @Description Check a brew request for errors and questionable combinations without creating anything; a body that cannot be bound is reported as errors too, never as a 400
+// @Tags brews
+// @Accept json
+// @Produce json
+// @Param body body models.CreateBrewRequest true "Brew data"
+// @Success 200 {object} models.BrewValidationReport
+// @Router /brews/validate [post]
+func (h *BrewHandler) Validate(c *gin.Context) {
+	var req models.CreateBrewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, newBrewValidationReport(bindingIssues(c, err)))
+		return
+	}
+	canonicalizeID(&req.TeapotID)
+	canonicalizeID(&req.TeaID)
+	canonicalizeID(req.BlendID)
+
+	teapot, tea, issues := h.checkBrewRequest(c, &req)
+
+	locale := i18n.FromContext(c)
+	if tea != nil {
+		issues = append(issues, checkWaterTemp(locale, *tea, req.WaterTempCelsius)...)
+	}
+	if teapot != nil {
+		issues = append(issues, checkTeapotCapacity(locale, *teapot)...)
+	}
+	if teapot != nil && tea != nil {
+		issues = append(issues, checkGongfuCapacity(locale, *teapot, *tea)...)
+		issues = append(issues, checkSeasoning(locale, *teapot, *tea, h.store.TeapotProfile(teapot.ID))...)
+	}
+
+	c.JSON(http.StatusOK, newBrewValidationReport(issues))
+}
+
+// checkBrewRequest runs the checks a brew must pass to be created: the
+// required temperature, the teapot, the blend and the tea. It resolves a
+// blend's primary tea into req and returns the teapot and tea it found, nil
+// when missing, along with the errors.
+func (h *BrewHandler) checkBrewRequest(c *gin.Context, req *models.CreateBrewRequest) (*models.Teapot, *models.Tea, []brewIssue) {
+	var issues []brewIssue
+
+	if h.requireTemp && req.WaterTempCelsius == nil {
+		issues = append(issues, brewIssue{
+			BrewValidationIssue: models.BrewValidationIssue{
+				Code:    "TEMP_REQUIRED",
+				Field:   "waterTempCelsius",
+				Message: i18n.Message(c, "waterTempCelsius is required"),
+			},
+			isError: true,
+		})
+	}
+
+	var teapot *models.Teapot
+	if found, ok := h.store.GetTeapot(req.TeapotID); ok {
+		teapot = &found
+	} else {
+		issues = append(issues, brewIssue{
+			BrewValidationIssue: models.BrewValidationIssue{
+				Code:    "NOT_FOUND",
+				Field:   "teapotId",
+				Message: i18n.Message(c, "Teapot not found"),
+			},
+			isError: true,
+		})
+	}
+
+	if issue := h.resolveBlend(c, req); issue != nil {
+		issues = append(issues, brewIssue{BrewValidationIssue: *issue, isError: true})
+	}
+
+	var tea *models.Tea
+	if found, ok := h.store.GetTea(req.TeaID); ok {
+		tea = &found
+	} else {
+		issues = append(issues, brewIssue{
+			BrewValidationIssue: models.BrewValidationIssue{
+				Code:    "NOT_FOUND",
+				Field:   "teaId",
+				Message: i18n.Message(c, "Tea not found"),
+			},
+			isError: true,
+		})
+	}
+
+	return teapot, tea, issues
+}
+
+// newBrewValidationReport sorts issues into errors and warnings
+func newBrewValidationReport(issues []brewIssue) models.BrewValidationReport {
+	report := models.BrewValidationReport{
+		Errors:   []models.BrewValidationIssue{},
+		Warnings: []models.BrewValidationIssue{},
+	}
+	for _, issue := range issues {
+		if issue.isError {
+			report.Errors = append(report.Errors, issue.BrewValidationIssue)
+		} else {
+			report.Warnings = append(report.Warnings, issue.BrewValidationIssue)
+		}
+	}
+	report.Valid = len(report.Errors) == 0
+	return report
+}
+
+// bindingIssues reports a request body that could not be bound as errors,
+// one per failed binding rule, with the codes bindingError would use
+func bindingIssues(c *gin.Context, err error) []brewIssue {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		bindErr := bindingError(c, err)
+		return []brewIssue{{
+			BrewValidationIssue: models.BrewValidationIssue{
+				Code:    bindErr.Code,
+				Field:   bindErr.Details["field"],
+				Message: bindErr.Message,
+			},
+			isError: true,
+		}}
+	}
+
+	locale := i18n.FromContext(c)
+	issues := make([]brewIssue, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		issues = append(issues, brewIssue{
+			BrewValidationIssue: models.BrewValidationIssue{
+				Code:    "VALIDATION_ERROR",
+				Field:   fieldName(fe.Field()),
+				Message: validationMessage(locale, fe),
+			},
+			isError: true,
+		})
+	}
+	return issues
+}
+
+// checkWaterTemp compares the requested water temperature with the tea's recommendation
+func checkWaterTemp(locale i18n.Locale, tea models.Tea, waterTemp *int) []brewIssue {
+	if waterTemp == nil {
+		return nil
+	}
+
+	delta := *waterTemp - tea.SteepTempCelsius
+	if delta < 0 {
+		delta = -delta
+	}
+	if delta <= tempWarningDelta {
+		return nil
+	}
+
+	return []brewIssue{{
+		BrewValidationIssue: models.BrewValidationIssue{
+			Code:  "TEMP_MISMATCH",
+			Field: "waterTempCelsius",
+			Message: i18n.Sprintf(locale, "Water temperature %d°C is %d°C away from the tea's recommended %d°C",
+				*waterTemp, delta, tea.SteepTempCelsius),
+		},
+		isError: delta > tempErrorDelta,
+	}}
+}
+
+// checkTeapotCapacity flags teapots too small for a practical brew
+func checkTeapotCapacity(locale i18n.Locale, teapot models.Teapot) []brewIssue {
+	if teapot.CapacityMl >= minBrewCapacityMl {
+		return nil
+	}
+
+	return []brewIssue{{
+		BrewValidationIssue: models.BrewValidationIssue{
+			Code:    "CAPACITY_TOO_SMALL",
+			Field:   "teapotId",
+			Message: i18n.Sprintf(locale, "Teapot holds only %dml; at least %dml is recommended", teapot.CapacityMl, minBrewCapacityMl),
+		},
+	}}
+}
+
+// checkGongfuCapacity flags gongfu-style teas brewed in oversized teapots
+func checkGongfuCapacity(locale i18n.Locale, teapot models.Teapot, tea models.Tea) []brewIssue {
+	if !gongfuTeaTypes[tea.Type] || teapot.CapacityMl <= maxGongfuCapacityMl {
+		return nil
+	}
+
+	return []brewIssue{{
+		BrewValidationIssue: models.BrewValidationIssue{
+			Code:    "CAPACITY_TOO_LARGE",
+			Field:   "teapotId",
+			Message: i18n.Sprintf(locale, "%s tea is best brewed in teapots of %dml or less", tea.Type, maxGongfuCapacityMl),
+		},
+	}}
+}
+
+// checkSeasoning flags unglazed clay teapots being used for a different tea
+// type than the one they have been brewed with most
+func checkSeasoning(locale i18n.Locale, teapot models.Teapot, tea models.Tea, profile *models.TeapotProfile) []brewIssue {
+	if teapot.Material != models.MaterialClay && teapot.Style != models.StyleYixing {
+		return nil
+	}
+	if profile == nil || profile.PreferredType == tea.Type {
+		return nil
+	}
+
+	return []brewIssue{{
+		BrewValidationIssue: models.BrewValidationIssue{
+			Code:    "SEASONING_MISMATCH",
+			Field:   "teaId",
+			Message: i18n.Sprintf(locale, "Teapot is seasoned with %s tea; brewing %s tea will mix flavors", profile.PreferredType, tea.Type),
+		},
+	}}
+}
@@ -0,0 +1,205 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/api2spec/api2spec-fixture-gin/internal/handlers"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBrewValidateRouter(t *testing.T, s *store.MemoryStore) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := handlers.NewBrewHandler(s)
+	router.POST("/brews/validate", handler.Validate)
+	return router
+}
+
+func issueCodes(issues []models.BrewValidationIssue) []string {
+	codes := []string{}
+	for _, issue := range issues {
+		codes = append(codes, issue.Code)
+	}
+	return codes
+}
+
+func TestBrewHandler_Validate(t *testing.T) {
+	tests := []struct {
+		name             string
+		setupStore       func(*testing.T, *store.MemoryStore) models.CreateBrewRequest
+		expectedValid    bool
+		expectedErrors   []string
+		expectedWarnings []string
+	}{
+		{
+			name: "good combination",
+			setupStore: func(t *testing.T, s *store.MemoryStore) models.CreateBrewRequest {
+				return models.CreateBrewRequest{
+					TeapotID:         createTestTeapot(t, s),
+					TeaID:            createTestTea(t, s),
+					WaterTempCelsius: intPtr(95),
+				}
+			},
+			expectedValid:    true,
+			expectedErrors:   []string{},
+			expectedWarnings: []string{},
+		},
+		{
+			name: "mismatched combination",
+			setupStore: func(t *testing.T, s *store.MemoryStore) models.CreateBrewRequest {
+				teapotID := uuid.New().String()
+				s.CreateTeapot(models.Teapot{
+					ID:         teapotID,
+					Name:       "Big Clay Pot",
+					Material:   models.MaterialClay,
+					CapacityMl: 1500,
+					Style:      models.StyleYixing,
+				})
+				greenID := uuid.New().String()
+				s.CreateTea(models.Tea{
+					ID:               greenID,
+					Name:             "Sencha",
+					Type:             models.TeaGreen,
+					CaffeineLevel:    models.CaffeineMedium,
+					SteepTempCelsius: 75,
+					SteepTimeSeconds: 90,
+				})
+				s.CreateBrew(models.Brew{
+					ID:               uuid.New().String(),
+					TeapotID:         teapotID,
+					TeaID:            greenID,
+					Status:           models.BrewServed,
+					WaterTempCelsius: 75,
+					StartedAt:        time.Now(),
+					CreatedAt:        time.Now(),
+					UpdatedAt:        time.Now(),
+				})
+				oolongID := uuid.New().String()
+				s.CreateTea(models.Tea{
+					ID:               oolongID,
+					Name:             "Tieguanyin",
+					Type:             models.TeaOolong,
+					CaffeineLevel:    models.CaffeineMedium,
+					SteepTempCelsius: 90,
+					SteepTimeSeconds: 60,
+				})
+				return models.CreateBrewRequest{
+					TeapotID:         teapotID,
+					TeaID:            oolongID,
+					WaterTempCelsius: intPtr(82),
+				}
+			},
+			expectedValid:    true,
+			expectedErrors:   []string{},
+			expectedWarnings: []string{"TEMP_MISMATCH", "CAPACITY_TOO_LARGE", "SEASONING_MISMATCH"},
+		},
+		{
+			name: "far-off temperature and missing teapot",
+			setupStore: func(t *testing.T, s *store.MemoryStore) models.CreateBrewRequest {
+				return models.CreateBrewRequest{
+					TeapotID:         uuid.New().String(),
+					TeaID:            createTestTea(t, s),
+					WaterTempCelsius: intPtr(70),
+				}
+			},
+			expectedValid:    false,
+			expectedErrors:   []string{"NOT_FOUND", "TEMP_MISMATCH"},
+			expectedWarnings: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := store.NewMemoryStore()
+			reqBody := tt.setupStore(t, s)
+			_, brewsBefore := s.ListBrews(models.BrewQuery{PaginationQuery: models.PaginationQuery{Page: 1, Limit: 100}})
+			router := setupBrewValidateRouter(t, s)
+
+			body, _ := json.Marshal(reqBody)
+			req := httptest.NewRequest(http.MethodPost, "/brews/validate", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response models.BrewValidationReport
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedValid, response.Valid)
+			assert.ElementsMatch(t, tt.expectedErrors, issueCodes(response.Errors))
+			assert.ElementsMatch(t, tt.expectedWarnings, issueCodes(response.Warnings))
+
+			// Validation never creates anything
+			_, brewsAfter := s.ListBrews(models.BrewQuery{PaginationQuery: models.PaginationQuery{Page: 1, Limit: 100}})
+			assert.Equal(t, brewsBefore, brewsAfter)
+		})
+	}
+}
+
+func TestBrewHandler_ValidateBindingFailures(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedCodes  []string
+		expectedFields []string
+	}{
+		{
+			name:           "malformed JSON",
+			body:           `{"teapotId":`,
+			expectedCodes:  []string{"MALFORMED_JSON"},
+			expectedFields: []string{""},
+		},
+		{
+			name:           "wrong field type",
+			body:           `{"teapotId":"` + uuid.New().String() + `","teaId":"` + uuid.New().String() + `","waterTempCelsius":"hot"}`,
+			expectedCodes:  []string{"MALFORMED_JSON"},
+			expectedFields: []string{"waterTempCelsius"},
+		},
+		{
+			name:           "broken binding rules",
+			body:           `{"teapotId":"not-a-uuid","waterTempCelsius":20}`,
+			expectedCodes:  []string{"VALIDATION_ERROR", "VALIDATION_ERROR", "VALIDATION_ERROR"},
+			expectedFields: []string{"teapotId", "teaId", "waterTempCelsius"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupBrewValidateRouter(t, store.NewMemoryStore())
+
+			req := httptest.NewRequest(http.MethodPost, "/brews/validate", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response models.BrewValidationReport
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			assert.False(t, response.Valid)
+			assert.Equal(t, tt.expectedCodes, issueCodes(response.Errors))
+			fields := []string{}
+			for _, issue := range response.Errors {
+				fields = append(fields, issue.Field)
+			}
+			assert.ElementsMatch(t, tt.expectedFields, fields)
+			assert.Empty(t, response.Warnings)
+		})
+	}
+}
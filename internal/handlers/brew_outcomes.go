@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+)
+
+// ByMaterial godoc
+// @Summary Brew outcomes by teapot material
+// @Description Get brew counts and average steep rating per teapot material; materials without brews are omitted
+// @Tags brews
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.MaterialOutcomeResponse
+// @Router /brews/by-material [get]
+func (h *BrewHandler) ByMaterial(c *gin.Context) {
+	c.JSON(http.StatusOK, models.MaterialOutcomeResponse{
+		Data: h.store.BrewOutcomesByMaterial(),
+	})
+}
@@ -0,0 +1,97 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/api2spec/api2spec-fixture-gin/internal/handlers"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrewHandler_ByMaterial(t *testing.T) {
+	s := store.NewMemoryStore()
+	teaID := createTestTea(t, s)
+
+	newTeapot := func(material models.TeapotMaterial) string {
+		id := uuid.New().String()
+		s.CreateTeapot(models.Teapot{
+			ID:         id,
+			Name:       string(material) + " teapot",
+			Material:   material,
+			CapacityMl: 500,
+			Style:      models.StyleEnglish,
+		})
+		return id
+	}
+	newBrew := func(teapotID string, ratings ...int) {
+		brewID := uuid.New().String()
+		s.CreateBrew(models.Brew{
+			ID:               brewID,
+			TeapotID:         teapotID,
+			TeaID:            teaID,
+			Status:           models.BrewServed,
+			WaterTempCelsius: 95,
+			StartedAt:        time.Now(),
+			CreatedAt:        time.Now(),
+			UpdatedAt:        time.Now(),
+		})
+		for i, rating := range ratings {
+			s.CreateSteep(models.Steep{
+				ID:              uuid.New().String(),
+				BrewID:          brewID,
+				SteepNumber:     i + 1,
+				DurationSeconds: 60,
+				Rating:          intPtr(rating),
+				CreatedAt:       time.Now(),
+			})
+		}
+	}
+
+	ceramicID := newTeapot(models.MaterialCeramic)
+	clayID := newTeapot(models.MaterialClay)
+	newTeapot(models.MaterialGlass) // no brews, omitted
+
+	newBrew(ceramicID, 3, 2)
+	newBrew(ceramicID)
+	newBrew(clayID, 5, 4, 5)
+
+	// Brews of a deleted teapot cannot be attributed to a material
+	deletedID := newTeapot(models.MaterialCastIron)
+	newBrew(deletedID, 1)
+	require.True(t, s.DeleteTeapot(deletedID))
+
+	router := gin.New()
+	router.GET("/brews/by-material", handlers.NewBrewHandler(s).ByMaterial)
+
+	req := httptest.NewRequest(http.MethodGet, "/brews/by-material", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.MaterialOutcomeResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data, 2)
+
+	ceramic, clay := response.Data[0], response.Data[1]
+	assert.Equal(t, models.MaterialCeramic, ceramic.Material)
+	assert.Equal(t, 2, ceramic.BrewCount)
+	assert.Equal(t, 2, ceramic.RatedSteeps)
+	require.NotNil(t, ceramic.AverageRating)
+	assert.InDelta(t, 2.5, *ceramic.AverageRating, 0.001)
+
+	assert.Equal(t, models.MaterialClay, clay.Material)
+	assert.Equal(t, 1, clay.BrewCount)
+	assert.Equal(t, 3, clay.RatedSteeps)
+	require.NotNil(t, clay.AverageRating)
+	assert.InDelta(t, 4.67, *clay.AverageRating, 0.001)
+}
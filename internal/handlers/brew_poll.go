@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+)
+
+const (
+	// DefaultPollTimeout is how long a poll waits when no timeout is given
+	DefaultPollTimeout = 30 * time.Second
+	// MaxPollTimeout caps the timeout a client may request
+	MaxPollTimeout = 60 * time.Second
+	// DefaultPollLimit is how many brews a poll returns when no limit is given
+	DefaultPollLimit = 100
+)
+
+// Poll godoc
+// @Summary Long-poll for brew changes
+// @Description Return brews created or updated after since, oldest first, waiting up to timeout seconds for one if there are none yet; pass until as the next since to page forward. Deleted brews are not reported.
+// @Tags brews
+// @Accept json
+// @Produce json
+// @Param since query string true "Only return brews changed after this time" format(date-time)
+// @Param timeout query int false "Seconds to wait for a change, capped at 60" default(30) minimum(0)
+// @Param limit query int false "Maximum brews to return" default(100) minimum(1) maximum(100)
+// @Success 200 {object} models.BrewPollResponse
+// @Failure 400 {object} models.Error
+// @Router /brews/poll [get]
+func (h *BrewHandler) Poll(c *gin.Context) {
+	var query models.BrewPollQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
+		return
+	}
+
+	timeout := DefaultPollTimeout
+	if query.Timeout != nil {
+		timeout = time.Duration(*query.Timeout) * time.Second
+	}
+	if timeout > MaxPollTimeout {
+		timeout = MaxPollTimeout
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPollLimit
+	}
+
+	// A cached answer would skip the wait
+	c.Header("Cache-Control", "no-store")
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		brews, changed := h.store.BrewsUpdatedSince(query.Since)
+		if len(brews) > 0 {
+			page := limitPoll(brews, query.Limit)
+			c.JSON(http.StatusOK, models.BrewPollResponse{
+				Data:    page,
+				Until:   page[len(page)-1].UpdatedAt,
+				HasMore: len(page) < len(brews),
+			})
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			c.JSON(http.StatusOK, models.BrewPollResponse{
+				Data:  brews,
+				Until: query.Since,
+			})
+			return
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+// limitPoll keeps the first limit brews, plus any that share the last one's
+// UpdatedAt; the next poll starts after that time, so splitting them would
+// skip the rest
+func limitPoll(brews []models.Brew, limit int) []models.Brew {
+	if len(brews) <= limit {
+		return brews
+	}
+	end := limit
+	for end < len(brews) && brews[end].UpdatedAt.Equal(brews[limit-1].UpdatedAt) {
+		end++
+	}
+	return brews[:end]
+}
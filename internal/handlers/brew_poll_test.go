@@ -0,0 +1,192 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/api2spec/api2spec-fixture-gin/internal/handlers"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBrewPollRouter(s *store.MemoryStore) *gin.Engine {
+	router := gin.New()
+	router.GET("/brews/poll", handlers.NewBrewHandler(s).Poll)
+	return router
+}
+
+func pollURL(since time.Time, timeout string) string {
+	query := url.Values{"since": {since.UTC().Format(time.RFC3339Nano)}}
+	if timeout != "" {
+		query.Set("timeout", timeout)
+	}
+	return "/brews/poll?" + query.Encode()
+}
+
+func TestBrewHandler_PollWaitsForCreate(t *testing.T) {
+	s := store.NewMemoryStore()
+	teapotID := createTestTeapot(t, s)
+	teaID := createTestTea(t, s)
+	router := setupBrewPollRouter(s)
+
+	since := time.Now().UTC()
+	brewID := uuid.New().String()
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		now := time.Now().UTC()
+		s.CreateBrew(models.Brew{
+			ID:               brewID,
+			TeapotID:         teapotID,
+			TeaID:            teaID,
+			Status:           models.BrewPreparing,
+			WaterTempCelsius: 95,
+			StartedAt:        now,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		})
+	}()
+
+	req := httptest.NewRequest(http.MethodGet, pollURL(since, "5"), nil)
+	w := httptest.NewRecorder()
+
+	started := time.Now()
+	router.ServeHTTP(w, req)
+
+	assert.Less(t, time.Since(started), 5*time.Second)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.BrewPollResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, brewID, response.Data[0].ID)
+	assert.True(t, response.Until.Equal(response.Data[0].UpdatedAt))
+}
+
+func TestBrewHandler_Poll(t *testing.T) {
+	s := store.NewMemoryStore()
+	teapotID := createTestTeapot(t, s)
+	teaID := createTestTea(t, s)
+	updatedAt := time.Now().UTC().Add(-time.Minute)
+	s.CreateBrew(models.Brew{
+		ID:               uuid.New().String(),
+		TeapotID:         teapotID,
+		TeaID:            teaID,
+		Status:           models.BrewPreparing,
+		WaterTempCelsius: 95,
+		StartedAt:        updatedAt,
+		CreatedAt:        updatedAt,
+		UpdatedAt:        updatedAt,
+	})
+	router := setupBrewPollRouter(s)
+
+	tests := []struct {
+		name           string
+		url            string
+		expectedStatus int
+		expectedCount  int
+	}{
+		{
+			name:           "existing change returns immediately",
+			url:            pollURL(updatedAt.Add(-time.Second), "30"),
+			expectedStatus: http.StatusOK,
+			expectedCount:  1,
+		},
+		{
+			name:           "nothing new within timeout",
+			url:            pollURL(updatedAt, "0"),
+			expectedStatus: http.StatusOK,
+			expectedCount:  0,
+		},
+		{
+			name:           "missing since",
+			url:            "/brews/poll?timeout=1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "malformed since",
+			url:            "/brews/poll?since=yesterday",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "negative timeout",
+			url:            pollURL(updatedAt, "-1"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "limit above maximum",
+			url:            pollURL(updatedAt, "0") + "&limit=101",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				assertErrorResponse(t, w)
+				return
+			}
+
+			var response models.BrewPollResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Len(t, response.Data, tt.expectedCount)
+			assert.NotNil(t, response.Data)
+		})
+	}
+}
+
+func TestBrewHandler_PollPagesForward(t *testing.T) {
+	s := store.NewMemoryStore()
+	teapotID := createTestTeapot(t, s)
+	teaID := createTestTea(t, s)
+	start := time.Now().UTC().Add(-time.Hour)
+	// Two brews share the second timestamp, so a page of two must not split them
+	for _, offset := range []time.Duration{time.Minute, 2 * time.Minute, 2 * time.Minute, 3 * time.Minute} {
+		updatedAt := start.Add(offset)
+		require.NoError(t, s.CreateBrew(models.Brew{
+			ID:               uuid.New().String(),
+			TeapotID:         teapotID,
+			TeaID:            teaID,
+			Status:           models.BrewPreparing,
+			WaterTempCelsius: 95,
+			StartedAt:        updatedAt,
+			CreatedAt:        updatedAt,
+			UpdatedAt:        updatedAt,
+		}))
+	}
+	router := setupBrewPollRouter(s)
+
+	poll := func(since time.Time) models.BrewPollResponse {
+		req := httptest.NewRequest(http.MethodGet, pollURL(since, "0")+"&limit=2", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response models.BrewPollResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	first := poll(start)
+	assert.Len(t, first.Data, 3)
+	assert.True(t, first.HasMore)
+	assert.True(t, first.Until.Equal(start.Add(2*time.Minute)))
+
+	second := poll(first.Until)
+	assert.Len(t, second.Data, 1)
+	assert.False(t, second.HasMore)
+	assert.True(t, second.Until.Equal(start.Add(3*time.Minute)))
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+)
+
+// brewStatuses is the single source for status presentation and allowed
+// transitions, listed in lifecycle order
+var brewStatuses = []models.BrewStatusMeta{
+	{Status: models.BrewPreparing, Label: "Preparing", Color: "#9E9E9E", Next: []models.BrewStatus{models.BrewSteeping, models.BrewCold}},
+	{Status: models.BrewSteeping, Label: "Steeping", Color: "#2E7D32", Next: []models.BrewStatus{models.BrewReady, models.BrewCold}},
+	{Status: models.BrewReady, Label: "Ready", Color: "#F9A825", Next: []models.BrewStatus{models.BrewServed, models.BrewCold}},
+	{Status: models.BrewServed, Label: "Served", Color: "#1565C0", Next: []models.BrewStatus{models.BrewCold}},
+	{Status: models.BrewCold, Label: "Cold", Color: "#4FC3F7", Next: []models.BrewStatus{}},
+}
+
+// canTransition reports whether a brew may move from one status to another;
+// staying in the same status is always allowed
+func canTransition(from, to models.BrewStatus) bool {
+	if from == to {
+		return true
+	}
+	for _, meta := range brewStatuses {
+		if meta.Status != from {
+			continue
+		}
+		for _, next := range meta.Next {
+			if next == to {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// StatusMeta godoc
+// @Summary Brew status metadata
+// @Description Get every brew status with its display label, color, ordering and allowed next statuses
+// @Tags brews
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.BrewStatusMetaResponse
+// @Router /brews/status-meta [get]
+func (h *BrewHandler) StatusMeta(c *gin.Context) {
+	data := make([]models.BrewStatusMeta, len(brewStatuses))
+	for i, meta := range brewStatuses {
+		meta.Order = i + 1
+		data[i] = meta
+	}
+
+	c.JSON(http.StatusOK, models.BrewStatusMetaResponse{Data: data})
+}
@@ -0,0 +1,49 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/handlers"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrewHandler_StatusMeta(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := handlers.NewBrewHandler(store.NewMemoryStore())
+	router.GET("/brews/status-meta", handler.StatusMeta)
+
+	req := httptest.NewRequest(http.MethodGet, "/brews/status-meta", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.BrewStatusMetaResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	expected := []models.BrewStatus{
+		models.BrewPreparing,
+		models.BrewSteeping,
+		models.BrewReady,
+		models.BrewServed,
+		models.BrewCold,
+	}
+	require.Len(t, response.Data, len(expected))
+	for i, meta := range response.Data {
+		assert.Equal(t, expected[i], meta.Status)
+		assert.Equal(t, i+1, meta.Order)
+		assert.NotEmpty(t, meta.Label)
+		assert.NotEmpty(t, meta.Color)
+		assert.NotNil(t, meta.Next)
+	}
+}
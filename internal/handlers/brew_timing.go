@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+)
+
+// isPaused reports whether the brew has an open pause interval
+func isPaused(b models.Brew) bool {
+	return len(b.Pauses) > 0 && b.Pauses[len(b.Pauses)-1].ResumedAt == nil
+}
+
+// activeDuration returns how long the brew has been steeping as of now,
+// excluding paused intervals and stopping at CompletedAt if set. Time spent
+// preparing does not count; brews recorded before SteepingAt existed are
+// measured from StartedAt.
+func activeDuration(b models.Brew, now time.Time) time.Duration {
+	start := b.StartedAt
+	if b.SteepingAt != nil {
+		start = *b.SteepingAt
+	} else if b.Status == models.BrewPreparing {
+		return 0
+	}
+
+	end := now
+	if b.CompletedAt != nil {
+		end = *b.CompletedAt
+	}
+
+	active := end.Sub(start)
+	for _, p := range b.Pauses {
+		paused := p.PausedAt
+		if paused.Before(start) {
+			paused = start
+		}
+		resumed := end
+		if p.ResumedAt != nil && p.ResumedAt.Before(end) {
+			resumed = *p.ResumedAt
+		}
+		if resumed.After(paused) {
+			active -= resumed.Sub(paused)
+		}
+	}
+
+	if active < 0 {
+		return 0
+	}
+	return active
+}
+
+// maxBrewUpdateAttempts bounds how often a read-modify-write on a brew is
+// retried when another write lands in between
+const maxBrewUpdateAttempts = 3
+
+// updateBrew applies change to the stored brew and stores the result only if
+// no other write landed in between, retrying on a conflicting write. change
+// returns an error to reject the update with a 409. It writes the error
+// response and returns false on failure.
+func (h *BrewHandler) updateBrew(c *gin.Context, id string, change func(models.Brew, time.Time) (models.Brew, *models.Error)) (models.Brew, bool) {
+	for attempt := 0; attempt < maxBrewUpdateAttempts; attempt++ {
+		existing, found := h.store.GetBrew(id)
+		if !found {
+			c.JSON(http.StatusNotFound, models.Error{
+				Code:    "NOT_FOUND",
+				Message: i18n.Message(c, "Brew not found"),
+			})
+			return models.Brew{}, false
+		}
+
+		updated, problem := change(existing, h.clock.Now())
+		if problem != nil {
+			c.JSON(http.StatusConflict, *problem)
+			return models.Brew{}, false
+		}
+		if h.store.CompareAndUpdateBrew(existing, updated) {
+			return updated, true
+		}
+	}
+
+	c.JSON(http.StatusConflict, models.Error{
+		Code:    "CONFLICT",
+		Message: i18n.Message(c, "Brew was modified concurrently; try again"),
+	})
+	return models.Brew{}, false
+}
+
+// Pause godoc
+// @Summary Pause a brew
+// @Description Pause a steeping brew so the paused time is excluded from its active time
+// @Tags brews
+// @Accept json
+// @Produce json
+// @Param id path string true "Brew ID" format(uuid)
+// @Success 200 {object} models.BrewDetail
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Router /brews/{id}/pause [post]
+func (h *BrewHandler) Pause(c *gin.Context) {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{
+			Code:    "VALIDATION_ERROR",
+			Message: i18n.Message(c, "Invalid brew ID format"),
+		})
+		return
+	}
+
+	brew, ok := h.updateBrew(c, id, func(brew models.Brew, now time.Time) (models.Brew, *models.Error) {
+		if brew.Status != models.BrewSteeping {
+			return brew, &models.Error{
+				Code:    "INVALID_STATE",
+				Message: i18n.Message(c, "Only steeping brews can be paused"),
+			}
+		}
+		if isPaused(brew) {
+			return brew, &models.Error{
+				Code:    "CONFLICT",
+				Message: i18n.Message(c, "Brew is already paused"),
+			}
+		}
+		brew.Pauses = append(append([]models.BrewPause{}, brew.Pauses...), models.BrewPause{PausedAt: now})
+		brew.UpdatedAt = now
+		return brew, nil
+	})
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BrewDetail{
+		Brew:          brew,
+		ActiveSeconds: int64(activeDuration(brew, brew.UpdatedAt) / time.Second),
+	})
+}
+
+// Resume godoc
+// @Summary Resume a paused brew
+// @Description Close the open pause interval of a steeping brew
+// @Tags brews
+// @Accept json
+// @Produce json
+// @Param id path string true "Brew ID" format(uuid)
+// @Success 200 {object} models.BrewDetail
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Router /brews/{id}/resume [post]
+func (h *BrewHandler) Resume(c *gin.Context) {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{
+			Code:    "VALIDATION_ERROR",
+			Message: i18n.Message(c, "Invalid brew ID format"),
+		})
+		return
+	}
+
+	brew, ok := h.updateBrew(c, id, func(brew models.Brew, now time.Time) (models.Brew, *models.Error) {
+		if brew.Status != models.BrewSteeping {
+			return brew, &models.Error{
+				Code:    "INVALID_STATE",
+				Message: i18n.Message(c, "Only steeping brews can be resumed"),
+			}
+		}
+		if !isPaused(brew) {
+			return brew, &models.Error{
+				Code:    "CONFLICT",
+				Message: i18n.Message(c, "Brew is not paused"),
+			}
+		}
+		brew.Pauses = append([]models.BrewPause{}, brew.Pauses...)
+		brew.Pauses[len(brew.Pauses)-1].ResumedAt = &now
+		brew.UpdatedAt = now
+		return brew, nil
+	})
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BrewDetail{
+		Brew:          brew,
+		ActiveSeconds: int64(activeDuration(brew, brew.UpdatedAt) / time.Second),
+	})
+}
+
+// Progress godoc
+// @Summary Get brew progress
+// @Description Get the percentage of the tea's recommended steep time that has elapsed, excluding paused time
+// @Tags brews
+// @Accept json
+// @Produce json
+// @Param id path string true "Brew ID" format(uuid)
+// @Success 200 {object} models.BrewProgress
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /brews/{id}/progress [get]
+func (h *BrewHandler) Progress(c *gin.Context) {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{
+			Code:    "VALIDATION_ERROR",
+			Message: i18n.Message(c, "Invalid brew ID format"),
+		})
+		return
+	}
+
+	brew, found := h.store.GetBrew(id)
+	if !found {
+		c.JSON(http.StatusNotFound, models.Error{
+			Code:    "NOT_FOUND",
+			Message: i18n.Message(c, "Brew not found"),
+		})
+		return
+	}
+
+	tea, found := h.store.GetTea(brew.TeaID)
+	if !found {
+		c.JSON(http.StatusNotFound, models.Error{
+			Code:    "NOT_FOUND",
+			Message: i18n.Message(c, "Tea not found"),
+		})
+		return
+	}
+
+	// Create validation keeps steep times positive, but a BeforeCreate hook or
+	// a PUT can store zero; such a steep is done as soon as it starts
+	target := max(int64(tea.SteepTimeSeconds), 0)
+	elapsed := int64(activeDuration(brew, h.clock.Now()) / time.Second)
+
+	progress := models.BrewProgress{
+		BrewID:         brew.ID,
+		Status:         brew.Status,
+		ElapsedSeconds: elapsed,
+	}
+
+	switch brew.Status {
+	case models.BrewPreparing:
+		progress.Percent = 0
+		progress.ElapsedSeconds = 0
+		progress.RemainingSeconds = target
+	case models.BrewSteeping:
+		if target == 0 || elapsed >= target {
+			progress.Percent = 100
+		} else {
+			progress.Percent = int(elapsed * 100 / target)
+			progress.RemainingSeconds = target - elapsed
+		}
+	default:
+		progress.Percent = 100
+	}
+
+	// Progress changes every second, so it must never be served from a cache
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, progress)
+}
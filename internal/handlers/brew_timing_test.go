@@ -0,0 +1,356 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/api2spec/api2spec-fixture-gin/internal/clock"
+	"github.com/api2spec/api2spec-fixture-gin/internal/handlers"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBrewTimingRouter(t *testing.T, s *store.MemoryStore) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := handlers.NewBrewHandler(s)
+	router.GET("/brews/:id", handler.Get)
+	router.POST("/brews/:id/pause", handler.Pause)
+	router.POST("/brews/:id/resume", handler.Resume)
+	router.GET("/brews/:id/progress", handler.Progress)
+	return router
+}
+
+func createTestBrew(t *testing.T, s *store.MemoryStore, startedAt time.Time) string {
+	t.Helper()
+	id := uuid.New().String()
+	s.CreateBrew(models.Brew{
+		ID:               id,
+		TeapotID:         createTestTeapot(t, s),
+		TeaID:            createTestTea(t, s),
+		Status:           models.BrewSteeping,
+		WaterTempCelsius: 95,
+		StartedAt:        startedAt,
+		CreatedAt:        startedAt,
+		UpdatedAt:        startedAt,
+	})
+	return id
+}
+
+func TestBrewHandler_PauseResume(t *testing.T) {
+	s := store.NewMemoryStore()
+	id := createTestBrew(t, s, time.Now().Add(-time.Minute))
+	router := setupBrewTimingRouter(t, s)
+
+	steps := []struct {
+		action         string
+		expectedStatus int
+	}{
+		{action: "resume", expectedStatus: http.StatusConflict},
+		{action: "pause", expectedStatus: http.StatusOK},
+		{action: "pause", expectedStatus: http.StatusConflict},
+		{action: "resume", expectedStatus: http.StatusOK},
+		{action: "resume", expectedStatus: http.StatusConflict},
+	}
+
+	for _, step := range steps {
+		req := httptest.NewRequest(http.MethodPost, "/brews/"+id+"/"+step.action, nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, step.expectedStatus, w.Code, step.action)
+		if step.expectedStatus == http.StatusConflict {
+			assertErrorResponse(t, w)
+		}
+	}
+
+	brew, found := s.GetBrew(id)
+	require.True(t, found)
+	require.Len(t, brew.Pauses, 1)
+	require.NotNil(t, brew.Pauses[0].ResumedAt)
+	assert.False(t, brew.Pauses[0].ResumedAt.Before(brew.Pauses[0].PausedAt))
+}
+
+func TestBrewHandler_PauseResumeRequireSteeping(t *testing.T) {
+	tests := []struct {
+		name   string
+		status models.BrewStatus
+		action string
+	}{
+		{name: "pause preparing", status: models.BrewPreparing, action: "pause"},
+		{name: "pause served", status: models.BrewServed, action: "pause"},
+		{name: "resume cold", status: models.BrewCold, action: "resume"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := store.NewMemoryStore()
+			id := createTestBrew(t, s, time.Now().Add(-time.Minute))
+			brew, _ := s.GetBrew(id)
+			brew.Status = tt.status
+			if tt.action == "resume" {
+				brew.Pauses = []models.BrewPause{{PausedAt: time.Now()}}
+			}
+			s.UpdateBrew(brew)
+			router := setupBrewTimingRouter(t, s)
+
+			req := httptest.NewRequest(http.MethodPost, "/brews/"+id+"/"+tt.action, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusConflict, w.Code)
+			var errResp models.Error
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+			assert.Equal(t, "INVALID_STATE", errResp.Code)
+
+			stored, _ := s.GetBrew(id)
+			assert.Equal(t, brew.Pauses, stored.Pauses)
+		})
+	}
+}
+
+func TestBrewHandler_ConcurrentPauses(t *testing.T) {
+	s := store.NewMemoryStore()
+	id := createTestBrew(t, s, time.Now().Add(-time.Minute))
+	router := setupBrewTimingRouter(t, s)
+
+	const requests = 20
+	codes := make(chan int, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/brews/"+id+"/pause", nil))
+			codes <- w.Code
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	succeeded := 0
+	for code := range codes {
+		if code == http.StatusOK {
+			succeeded++
+		} else {
+			assert.Equal(t, http.StatusConflict, code)
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+
+	brew, _ := s.GetBrew(id)
+	assert.Len(t, brew.Pauses, 1)
+}
+
+func TestBrewHandler_GetActiveSeconds(t *testing.T) {
+	now := time.Now().UTC()
+	pausedAt := now.Add(-80 * time.Second)
+	resumedAt := now.Add(-50 * time.Second)
+
+	tests := []struct {
+		name     string
+		pauses   []models.BrewPause
+		expected int64
+	}{
+		{
+			name:     "never paused",
+			expected: 100,
+		},
+		{
+			name:     "resumed pause",
+			pauses:   []models.BrewPause{{PausedAt: pausedAt, ResumedAt: &resumedAt}},
+			expected: 70,
+		},
+		{
+			name:     "still paused",
+			pauses:   []models.BrewPause{{PausedAt: pausedAt}},
+			expected: 20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := store.NewMemoryStore()
+			id := createTestBrew(t, s, now.Add(-100*time.Second))
+			brew, _ := s.GetBrew(id)
+			brew.Pauses = tt.pauses
+			s.UpdateBrew(brew)
+			router := setupBrewTimingRouter(t, s)
+
+			req := httptest.NewRequest(http.MethodGet, "/brews/"+id, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response models.BrewDetail
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, response.ActiveSeconds, 1)
+		})
+	}
+}
+
+func TestBrewHandler_Progress(t *testing.T) {
+	// createTestTea recommends a 240 second steep
+	tests := []struct {
+		name              string
+		status            models.BrewStatus
+		startedAgo        time.Duration
+		steepTimeSeconds  *int
+		expectedPercent   int
+		expectedRemaining int64
+	}{
+		{
+			name:              "mid-steep",
+			status:            models.BrewSteeping,
+			startedAgo:        120 * time.Second,
+			expectedPercent:   50,
+			expectedRemaining: 120,
+		},
+		{
+			name:              "over-steep is capped",
+			status:            models.BrewSteeping,
+			startedAgo:        600 * time.Second,
+			expectedPercent:   100,
+			expectedRemaining: 0,
+		},
+		{
+			name:              "preparing",
+			status:            models.BrewPreparing,
+			startedAgo:        120 * time.Second,
+			expectedPercent:   0,
+			expectedRemaining: 240,
+		},
+		{
+			name:              "served",
+			status:            models.BrewServed,
+			startedAgo:        30 * time.Second,
+			expectedPercent:   100,
+			expectedRemaining: 0,
+		},
+		{
+			name:              "zero steep time while steeping",
+			status:            models.BrewSteeping,
+			startedAgo:        0,
+			steepTimeSeconds:  intPtr(0),
+			expectedPercent:   100,
+			expectedRemaining: 0,
+		},
+		{
+			name:              "zero steep time while preparing",
+			status:            models.BrewPreparing,
+			startedAgo:        0,
+			steepTimeSeconds:  intPtr(0),
+			expectedPercent:   0,
+			expectedRemaining: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := store.NewMemoryStore()
+			id := createTestBrew(t, s, time.Now().UTC().Add(-tt.startedAgo))
+			brew, _ := s.GetBrew(id)
+			brew.Status = tt.status
+			s.UpdateBrew(brew)
+			if tt.steepTimeSeconds != nil {
+				tea, _ := s.GetTea(brew.TeaID)
+				tea.SteepTimeSeconds = *tt.steepTimeSeconds
+				s.UpdateTea(tea)
+			}
+			router := setupBrewTimingRouter(t, s)
+
+			req := httptest.NewRequest(http.MethodGet, "/brews/"+id+"/progress", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response models.BrewProgress
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, response.Status)
+			assert.InDelta(t, tt.expectedPercent, response.Percent, 1)
+			assert.InDelta(t, tt.expectedRemaining, response.RemainingSeconds, 1)
+		})
+	}
+
+	t.Run("unknown brew", func(t *testing.T) {
+		router := setupBrewTimingRouter(t, store.NewMemoryStore())
+
+		req := httptest.NewRequest(http.MethodGet, "/brews/"+uuid.New().String()+"/progress", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestBrewHandler_ProgressMeasuresFromSteeping(t *testing.T) {
+	s := store.NewMemoryStore()
+	fake := clock.NewFake(time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC))
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := handlers.NewBrewHandlerWithOptions(s, handlers.BrewOptions{Clock: fake})
+	router.PATCH("/brews/:id", handler.Patch)
+	router.GET("/brews/:id/progress", handler.Progress)
+
+	// The brew sits in preparing for longer than the tea's 240 second steep
+	id := createTestBrew(t, s, fake.Now())
+	brew, _ := s.GetBrew(id)
+	brew.Status = models.BrewPreparing
+	s.UpdateBrew(brew)
+	fake.Advance(10 * time.Minute)
+
+	req := httptest.NewRequest(http.MethodPatch, "/brews/"+id, strings.NewReader(`{"status":"steeping"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	brew, _ = s.GetBrew(id)
+	require.NotNil(t, brew.SteepingAt)
+	assert.True(t, brew.SteepingAt.Equal(fake.Now()))
+
+	progress := func() models.BrewProgress {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/brews/"+id+"/progress", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response models.BrewProgress
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	response := progress()
+	assert.Equal(t, 0, response.Percent)
+	assert.Equal(t, int64(240), response.RemainingSeconds)
+
+	fake.Advance(120 * time.Second)
+	response = progress()
+	assert.Equal(t, 50, response.Percent)
+	assert.Equal(t, int64(120), response.ElapsedSeconds)
+
+	// The auto-completer measures from the same point
+	completer := handlers.NewBrewAutoCompleter(s, handlers.AutoCompleteOptions{Clock: fake})
+	assert.Zero(t, completer.Scan())
+	fake.Advance(120 * time.Second)
+	assert.Equal(t, 1, completer.Scan())
+}
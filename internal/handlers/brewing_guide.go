@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+)
+
+// guideTemplate describes how a tea type is re-steeped
+type guideTemplate struct {
+	steeps           int
+	incrementSeconds int
+}
+
+// guideTemplates holds the re-steeping pattern per tea type; the tea's own
+// temperature and time set the first steep
+var guideTemplates = map[models.TeaType]guideTemplate{
+	models.TeaGreen:   {steeps: 3, incrementSeconds: 30},
+	models.TeaBlack:   {steeps: 2, incrementSeconds: 60},
+	models.TeaOolong:  {steeps: 6, incrementSeconds: 15},
+	models.TeaWhite:   {steeps: 4, incrementSeconds: 30},
+	models.TeaPuerh:   {steeps: 8, incrementSeconds: 10},
+	models.TeaHerbal:  {steeps: 1, incrementSeconds: 0},
+	models.TeaRooibos: {steeps: 2, incrementSeconds: 60},
+}
+
+// BrewingGuide godoc
+// @Summary Get a brewing guide for a tea
+// @Description Get step-by-step multi-steep instructions from the tea's type and its own temperature and time
+// @Tags teas
+// @Accept json
+// @Produce json
+// @Param id path string true "Tea ID" format(uuid)
+// @Success 200 {object} models.BrewingGuide
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /teas/{id}/brewing-guide [get]
+func (h *TeaHandler) BrewingGuide(c *gin.Context) {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{
+			Code:    "VALIDATION_ERROR",
+			Message: i18n.Message(c, "Invalid tea ID format"),
+		})
+		return
+	}
+
+	tea, found := h.store.GetTea(id)
+	if !found {
+		c.JSON(http.StatusNotFound, models.Error{
+			Code:    "NOT_FOUND",
+			Message: i18n.Message(c, "Tea not found"),
+		})
+		return
+	}
+
+	template, ok := guideTemplates[tea.Type]
+	if !ok {
+		template = guideTemplate{steeps: 1}
+	}
+
+	steps := make([]models.BrewingGuideStep, 0, template.steeps)
+	for i := 0; i < template.steeps; i++ {
+		steps = append(steps, models.BrewingGuideStep{
+			SteepNumber:     i + 1,
+			TempCelsius:     tea.SteepTempCelsius,
+			DurationSeconds: tea.SteepTimeSeconds + i*template.incrementSeconds,
+		})
+	}
+
+	c.JSON(http.StatusOK, models.BrewingGuide{
+		TeaID:             tea.ID,
+		Type:              tea.Type,
+		TempCelsius:       tea.SteepTempCelsius,
+		FirstSteepSeconds: tea.SteepTimeSeconds,
+		IncrementSeconds:  template.incrementSeconds,
+		SteepCount:        template.steeps,
+		Steps:             steps,
+	})
+}
@@ -0,0 +1,111 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/api2spec/api2spec-fixture-gin/internal/handlers"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeaHandler_BrewingGuide(t *testing.T) {
+	s := store.NewMemoryStore()
+	greenID := uuid.New().String()
+	s.CreateTea(models.Tea{
+		ID:               greenID,
+		Name:             "Sencha",
+		Type:             models.TeaGreen,
+		CaffeineLevel:    models.CaffeineMedium,
+		SteepTempCelsius: 75,
+		SteepTimeSeconds: 60,
+	})
+	puerhID := uuid.New().String()
+	s.CreateTea(models.Tea{
+		ID:               puerhID,
+		Name:             "Shou Puerh",
+		Type:             models.TeaPuerh,
+		CaffeineLevel:    models.CaffeineHigh,
+		SteepTempCelsius: 100,
+		SteepTimeSeconds: 15,
+	})
+
+	router := gin.New()
+	router.GET("/teas/:id/brewing-guide", handlers.NewTeaHandler(s).BrewingGuide)
+
+	tests := []struct {
+		name           string
+		id             string
+		expectedStatus int
+		check          func(t *testing.T, guide models.BrewingGuide)
+	}{
+		{
+			name:           "green tea uses few long steeps",
+			id:             greenID,
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, guide models.BrewingGuide) {
+				assert.Equal(t, models.TeaGreen, guide.Type)
+				assert.Equal(t, 75, guide.TempCelsius)
+				assert.Equal(t, 60, guide.FirstSteepSeconds)
+				assert.Equal(t, 30, guide.IncrementSeconds)
+				assert.Equal(t, 3, guide.SteepCount)
+				assert.Equal(t, []models.BrewingGuideStep{
+					{SteepNumber: 1, TempCelsius: 75, DurationSeconds: 60},
+					{SteepNumber: 2, TempCelsius: 75, DurationSeconds: 90},
+					{SteepNumber: 3, TempCelsius: 75, DurationSeconds: 120},
+				}, guide.Steps)
+			},
+		},
+		{
+			name:           "puerh uses many short steeps",
+			id:             puerhID,
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, guide models.BrewingGuide) {
+				assert.Equal(t, models.TeaPuerh, guide.Type)
+				assert.Equal(t, 100, guide.TempCelsius)
+				assert.Equal(t, 15, guide.FirstSteepSeconds)
+				assert.Equal(t, 10, guide.IncrementSeconds)
+				assert.Equal(t, 8, guide.SteepCount)
+				require.Len(t, guide.Steps, 8)
+				assert.Equal(t, 15, guide.Steps[0].DurationSeconds)
+				assert.Equal(t, 85, guide.Steps[7].DurationSeconds)
+			},
+		},
+		{
+			name:           "non-existent tea",
+			id:             uuid.New().String(),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid ID",
+			id:             "invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/teas/"+tt.id+"/brewing-guide", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.check == nil {
+				assertErrorResponse(t, w)
+				return
+			}
+
+			var guide models.BrewingGuide
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &guide))
+			assert.Equal(t, tt.id, guide.TeaID)
+			tt.check(t, guide)
+		})
+	}
+}
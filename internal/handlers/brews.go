@@ -6,18 +6,66 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/api2spec/api2spec-fixture-gin/internal/clock"
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
 	"github.com/api2spec/api2spec-fixture-gin/internal/models"
 	"github.com/api2spec/api2spec-fixture-gin/internal/store"
 )
 
 // BrewHandler handles brew-related endpoints
 type BrewHandler struct {
-	store *store.MemoryStore
+	store         *store.MemoryStore
+	beforeCreate  BeforeCreate[models.Brew]
+	maxFutureSkew time.Duration
+	requireTemp   bool
+	clock         clock.Clock
+}
+
+// MaxEmbeddedSteeps caps the steeps embedded in a brew by includeSteeps
+const MaxEmbeddedSteeps = 50
+
+// DefaultMaxFutureSkew is how far in the future a client-supplied brew timestamp may be
+const DefaultMaxFutureSkew = 5 * time.Minute
+
+// BrewOptions configures optional brew handler behavior
+type BrewOptions struct {
+	// BeforeCreate runs on every new brew before it is stored
+	BeforeCreate BeforeCreate[models.Brew]
+	// MaxFutureSkew bounds client-supplied timestamps; zero or negative uses
+	// DefaultMaxFutureSkew, so some skew is always allowed
+	MaxFutureSkew time.Duration
+	// RequireTemp rejects new brews without an explicit waterTempCelsius
+	// instead of defaulting to the tea's recommended temperature
+	RequireTemp bool
+	// Clock supplies the current time; nil uses the system clock
+	Clock clock.Clock
 }
 
 // NewBrewHandler creates a new brew handler
 func NewBrewHandler(store *store.MemoryStore) *BrewHandler {
-	return &BrewHandler{store: store}
+	return NewBrewHandlerWithOptions(store, BrewOptions{})
+}
+
+// NewBrewHandlerWithHook creates a brew handler that runs hook on every new brew before storing it
+func NewBrewHandlerWithHook(store *store.MemoryStore, hook BeforeCreate[models.Brew]) *BrewHandler {
+	return NewBrewHandlerWithOptions(store, BrewOptions{BeforeCreate: hook})
+}
+
+// NewBrewHandlerWithOptions creates a brew handler with the given options
+func NewBrewHandlerWithOptions(store *store.MemoryStore, opts BrewOptions) *BrewHandler {
+	if opts.MaxFutureSkew <= 0 {
+		opts.MaxFutureSkew = DefaultMaxFutureSkew
+	}
+	if opts.Clock == nil {
+		opts.Clock = clock.System{}
+	}
+	return &BrewHandler{
+		store:         store,
+		beforeCreate:  opts.BeforeCreate,
+		maxFutureSkew: opts.MaxFutureSkew,
+		requireTemp:   opts.RequireTemp,
+		clock:         opts.Clock,
+	}
 }
 
 // List godoc
@@ -28,6 +76,7 @@ func NewBrewHandler(store *store.MemoryStore) *BrewHandler {
 // @Produce json
 // @Param page query int false "Page number" default(1) minimum(1)
 // @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param format query string false "Response format; ndjson streams every match, one per line, ignoring page and limit" Enums(json, ndjson) default(json)
 // @Param status query string false "Filter by status" Enums(preparing, steeping, ready, served, cold)
 // @Param teapotId query string false "Filter by teapot ID" format(uuid)
 // @Param teaId query string false "Filter by tea ID" format(uuid)
@@ -36,10 +85,14 @@ func NewBrewHandler(store *store.MemoryStore) *BrewHandler {
 func (h *BrewHandler) List(c *gin.Context) {
 	var query models.BrewQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Code:    "VALIDATION_ERROR",
-			Message: err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
+		return
+	}
+	canonicalizeID(query.TeapotID)
+	canonicalizeID(query.TeaID)
+
+	if query.Format == "ndjson" {
+		writeNDJSON(c, h.store.ListAllBrews(query))
 		return
 	}
 
@@ -70,39 +123,37 @@ func (h *BrewHandler) List(c *gin.Context) {
 
 // Create godoc
 // @Summary Create a brew
-// @Description Create a new brewing session
+// @Description Create a new brewing session from a tea or a blend; a blend without teaId brews its largest component, and a teaId sent with a blend must be one of its components. waterTempCelsius defaults to the tea's recommended temperature unless the server requires it
 // @Tags brews
 // @Accept json
 // @Produce json
 // @Param body body models.CreateBrewRequest true "Brew data"
 // @Success 201 {object} models.Brew
 // @Failure 400 {object} models.Error
+// @Failure 422 {object} models.Error
+// @Failure 500 {object} models.Error
 // @Router /brews [post]
 func (h *BrewHandler) Create(c *gin.Context) {
 	var req models.CreateBrewRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Code:    "VALIDATION_ERROR",
-			Message: err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
 		return
 	}
-
-	// Verify teapot exists
-	if _, found := h.store.GetTeapot(req.TeapotID); !found {
+	canonicalizeID(&req.TeapotID)
+	canonicalizeID(&req.TeaID)
+	canonicalizeID(req.BlendID)
+
+	// The same checks POST /brews/validate reports as errors; the first one
+	// found rejects the brew, with missing references as VALIDATION_ERROR
+	_, tea, issues := h.checkBrewRequest(c, &req)
+	if len(issues) > 0 {
+		code := issues[0].Code
+		if code == "NOT_FOUND" {
+			code = "VALIDATION_ERROR"
+		}
 		c.JSON(http.StatusBadRequest, models.Error{
-			Code:    "VALIDATION_ERROR",
-			Message: "Teapot not found",
-		})
-		return
-	}
-
-	// Verify tea exists and get default temp
-	tea, found := h.store.GetTea(req.TeaID)
-	if !found {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Code:    "VALIDATION_ERROR",
-			Message: "Tea not found",
+			Code:    code,
+			Message: issues[0].Message,
 		})
 		return
 	}
@@ -113,11 +164,12 @@ func (h *BrewHandler) Create(c *gin.Context) {
 		waterTemp = *req.WaterTempCelsius
 	}
 
-	now := time.Now().UTC()
+	now := h.clock.Now()
 	brew := models.Brew{
 		ID:               uuid.New().String(),
 		TeapotID:         req.TeapotID,
 		TeaID:            req.TeaID,
+		BlendID:          req.BlendID,
 		Status:           models.BrewPreparing,
 		WaterTempCelsius: waterTemp,
 		Notes:            req.Notes,
@@ -126,10 +178,59 @@ func (h *BrewHandler) Create(c *gin.Context) {
 		UpdatedAt:        now,
 	}
 
-	h.store.CreateBrew(brew)
+	brew, ok := h.beforeCreate.run(c, brew)
+	if !ok {
+		return
+	}
+
+	if err := h.store.CreateBrew(brew); err != nil {
+		respondIDCollision(c)
+		return
+	}
 	c.JSON(http.StatusCreated, brew)
 }
 
+// resolveBlend checks the request's blend and, when no tea is named, brews
+// the blend's primary tea. It returns the problem found, or nil: an unknown
+// blend, a tea that is not one of the blend's components, or a primary tea
+// that has since been deleted.
+func (h *BrewHandler) resolveBlend(c *gin.Context, req *models.CreateBrewRequest) *models.BrewValidationIssue {
+	if req.BlendID == nil {
+		return nil
+	}
+	blend, found := h.store.GetBlend(*req.BlendID)
+	if !found {
+		return &models.BrewValidationIssue{
+			Code:    "NOT_FOUND",
+			Field:   "blendId",
+			Message: i18n.Message(c, "Blend not found"),
+		}
+	}
+
+	if req.TeaID != "" {
+		for _, component := range blend.Components {
+			if component.TeaID == req.TeaID {
+				return nil
+			}
+		}
+		return &models.BrewValidationIssue{
+			Code:    "TEA_NOT_IN_BLEND",
+			Field:   "teaId",
+			Message: i18n.Message(c, "Tea %s is not a component of this blend", req.TeaID),
+		}
+	}
+
+	req.TeaID = blend.PrimaryTeaID()
+	if _, found := h.store.GetTea(req.TeaID); !found {
+		return &models.BrewValidationIssue{
+			Code:    "BLEND_TEA_MISSING",
+			Field:   "blendId",
+			Message: i18n.Message(c, "The blend's primary tea %s no longer exists", req.TeaID),
+		}
+	}
+	return nil
+}
+
 // Get godoc
 // @Summary Get a brew by ID
 // @Description Get a single brew by its UUID
@@ -137,17 +238,17 @@ func (h *BrewHandler) Create(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Brew ID" format(uuid)
-// @Success 200 {object} models.Brew
+// @Param includeSteeps query bool false "Embed the brew's steeps, ordered by number, up to 50"
+// @Success 200 {object} models.BrewDetailWithSteeps
 // @Failure 400 {object} models.Error
 // @Failure 404 {object} models.Error
 // @Router /brews/{id} [get]
 func (h *BrewHandler) Get(c *gin.Context) {
-	id := c.Param("id")
-
-	if _, err := uuid.Parse(id); err != nil {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.Error{
 			Code:    "VALIDATION_ERROR",
-			Message: "Invalid brew ID format",
+			Message: i18n.Message(c, "Invalid brew ID format"),
 		})
 		return
 	}
@@ -156,12 +257,41 @@ func (h *BrewHandler) Get(c *gin.Context) {
 	if !found {
 		c.JSON(http.StatusNotFound, models.Error{
 			Code:    "NOT_FOUND",
-			Message: "Brew not found",
+			Message: i18n.Message(c, "Brew not found"),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, brew)
+	var query models.BrewGetQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
+		return
+	}
+
+	detail := models.BrewDetail{
+		Brew:          brew,
+		ActiveSeconds: int64(activeDuration(brew, h.clock.Now()) / time.Second),
+	}
+	// activeSeconds keeps counting until the brew completes, so a cached copy would freeze it
+	if brew.CompletedAt == nil && brew.Status != models.BrewPreparing {
+		c.Header("Cache-Control", "no-store")
+	}
+	if !query.IncludeSteeps {
+		c.JSON(http.StatusOK, detail)
+		return
+	}
+
+	steeps := h.store.ListAllSteepsByBrew(id)
+	truncated := len(steeps) > MaxEmbeddedSteeps
+	if truncated {
+		steeps = steeps[:MaxEmbeddedSteeps]
+	}
+
+	c.JSON(http.StatusOK, models.BrewDetailWithSteeps{
+		BrewDetail:      detail,
+		Steeps:          steeps,
+		SteepsTruncated: truncated,
+	})
 }
 
 // Patch godoc
@@ -175,14 +305,15 @@ func (h *BrewHandler) Get(c *gin.Context) {
 // @Success 200 {object} models.Brew
 // @Failure 400 {object} models.Error
 // @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Failure 422 {object} models.Error
 // @Router /brews/{id} [patch]
 func (h *BrewHandler) Patch(c *gin.Context) {
-	id := c.Param("id")
-
-	if _, err := uuid.Parse(id); err != nil {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.Error{
 			Code:    "VALIDATION_ERROR",
-			Message: "Invalid brew ID format",
+			Message: i18n.Message(c, "Invalid brew ID format"),
 		})
 		return
 	}
@@ -191,22 +322,40 @@ func (h *BrewHandler) Patch(c *gin.Context) {
 	if !found {
 		c.JSON(http.StatusNotFound, models.Error{
 			Code:    "NOT_FOUND",
-			Message: "Brew not found",
+			Message: i18n.Message(c, "Brew not found"),
 		})
 		return
 	}
 
 	var req models.PatchBrewRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Code:    "VALIDATION_ERROR",
-			Message: err.Error(),
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
+		return
+	}
+
+	now := h.clock.Now()
+	if req.CompletedAt != nil && req.CompletedAt.After(now.Add(h.maxFutureSkew)) {
+		c.JSON(http.StatusUnprocessableEntity, models.Error{
+			Code:    "FUTURE_TIMESTAMP",
+			Message: i18n.Message(c, "completedAt is more than %s in the future", h.maxFutureSkew),
+		})
+		return
+	}
+
+	if req.Status != nil && !canTransition(existing.Status, *req.Status) {
+		c.JSON(http.StatusConflict, models.Error{
+			Code:    "INVALID_TRANSITION",
+			Message: i18n.Message(c, "Brew cannot move from %s to %s", existing.Status, *req.Status),
 		})
 		return
 	}
 
 	// Apply patches
 	if req.Status != nil {
+		if *req.Status == models.BrewSteeping && existing.Status != models.BrewSteeping {
+			steepingAt := now
+			existing.SteepingAt = &steepingAt
+		}
 		existing.Status = *req.Status
 	}
 	if req.Notes != nil {
@@ -215,7 +364,7 @@ func (h *BrewHandler) Patch(c *gin.Context) {
 	if req.CompletedAt != nil {
 		existing.CompletedAt = req.CompletedAt
 	}
-	existing.UpdatedAt = time.Now().UTC()
+	existing.UpdatedAt = now
 
 	h.store.UpdateBrew(existing)
 	c.JSON(http.StatusOK, existing)
@@ -233,12 +382,11 @@ func (h *BrewHandler) Patch(c *gin.Context) {
 // @Failure 404 {object} models.Error
 // @Router /brews/{id} [delete]
 func (h *BrewHandler) Delete(c *gin.Context) {
-	id := c.Param("id")
-
-	if _, err := uuid.Parse(id); err != nil {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.Error{
 			Code:    "VALIDATION_ERROR",
-			Message: "Invalid brew ID format",
+			Message: i18n.Message(c, "Invalid brew ID format"),
 		})
 		return
 	}
@@ -246,7 +394,7 @@ func (h *BrewHandler) Delete(c *gin.Context) {
 	if !h.store.DeleteBrew(id) {
 		c.JSON(http.StatusNotFound, models.Error{
 			Code:    "NOT_FOUND",
-			Message: "Brew not found",
+			Message: i18n.Message(c, "Brew not found"),
 		})
 		return
 	}
@@ -268,12 +416,11 @@ func (h *BrewHandler) Delete(c *gin.Context) {
 // @Failure 404 {object} models.Error
 // @Router /teapots/{teapotId}/brews [get]
 func (h *BrewHandler) ListByTeapot(c *gin.Context) {
-	teapotID := c.Param("id")
-
-	if _, err := uuid.Parse(teapotID); err != nil {
+	teapotID, err := normalizeID(c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.Error{
 			Code:    "VALIDATION_ERROR",
-			Message: "Invalid teapot ID format",
+			Message: i18n.Message(c, "Invalid teapot ID format"),
 		})
 		return
 	}
@@ -282,17 +429,14 @@ func (h *BrewHandler) ListByTeapot(c *gin.Context) {
 	if _, found := h.store.GetTeapot(teapotID); !found {
 		c.JSON(http.StatusNotFound, models.Error{
 			Code:    "NOT_FOUND",
-			Message: "Teapot not found",
+			Message: i18n.Message(c, "Teapot not found"),
 		})
 		return
 	}
 
 	var query models.PaginationQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Code:    "VALIDATION_ERROR",
-			Message: err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
 		return
 	}
 
@@ -321,6 +465,95 @@ func (h *BrewHandler) ListByTeapot(c *gin.Context) {
 	})
 }
 
+// ListByTea godoc
+// @Summary List brews by tea
+// @Description Get a paginated list of brews of a specific tea across all teapots
+// @Tags teas
+// @Accept json
+// @Produce json
+// @Param teaId path string true "Tea ID" format(uuid)
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param expand query string false "Embed related entities; teapot adds a teapot summary (null if deleted)" Enums(teapot)
+// @Success 200 {object} models.BrewWithTeapotListResponse
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /teas/{teaId}/brews [get]
+func (h *BrewHandler) ListByTea(c *gin.Context) {
+	teaID, err := normalizeID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{
+			Code:    "VALIDATION_ERROR",
+			Message: i18n.Message(c, "Invalid tea ID format"),
+		})
+		return
+	}
+
+	// Verify tea exists
+	if _, found := h.store.GetTea(teaID); !found {
+		c.JSON(http.StatusNotFound, models.Error{
+			Code:    "NOT_FOUND",
+			Message: i18n.Message(c, "Tea not found"),
+		})
+		return
+	}
+
+	var query models.TeaBrewsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
+		return
+	}
+
+	// Set defaults
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 20
+	}
+
+	brews, total := h.store.ListBrewsByTea(teaID, query.Page, query.Limit)
+	totalPages := (total + query.Limit - 1) / query.Limit
+	if totalPages < 0 {
+		totalPages = 0
+	}
+	pagination := models.Pagination{
+		Page:       query.Page,
+		Limit:      query.Limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	if query.Expand != "teapot" {
+		c.JSON(http.StatusOK, models.BrewListResponse{
+			Data:       brews,
+			Pagination: pagination,
+		})
+		return
+	}
+
+	// Resolve all teapots for the page at once rather than per brew
+	teapotIDs := make([]string, 0, len(brews))
+	for _, b := range brews {
+		teapotIDs = append(teapotIDs, b.TeapotID)
+	}
+	summaries := h.store.TeapotSummaries(teapotIDs)
+
+	expanded := make([]models.BrewWithTeapot, 0, len(brews))
+	for _, b := range brews {
+		item := models.BrewWithTeapot{Brew: b}
+		if summary, ok := summaries[b.TeapotID]; ok {
+			item.Teapot = &summary
+		}
+		expanded = append(expanded, item)
+	}
+
+	c.JSON(http.StatusOK, models.BrewWithTeapotListResponse{
+		Data:       expanded,
+		Pagination: pagination,
+	})
+}
+
 // ListSteeps godoc
 // @Summary List steeps for a brew
 // @Description Get a paginated list of steeps for a specific brew
@@ -335,12 +568,11 @@ func (h *BrewHandler) ListByTeapot(c *gin.Context) {
 // @Failure 404 {object} models.Error
 // @Router /brews/{brewId}/steeps [get]
 func (h *BrewHandler) ListSteeps(c *gin.Context) {
-	brewID := c.Param("id")
-
-	if _, err := uuid.Parse(brewID); err != nil {
+	brewID, err := normalizeID(c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.Error{
 			Code:    "VALIDATION_ERROR",
-			Message: "Invalid brew ID format",
+			Message: i18n.Message(c, "Invalid brew ID format"),
 		})
 		return
 	}
@@ -349,17 +581,14 @@ func (h *BrewHandler) ListSteeps(c *gin.Context) {
 	if _, found := h.store.GetBrew(brewID); !found {
 		c.JSON(http.StatusNotFound, models.Error{
 			Code:    "NOT_FOUND",
-			Message: "Brew not found",
+			Message: i18n.Message(c, "Brew not found"),
 		})
 		return
 	}
 
 	var query models.PaginationQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Code:    "VALIDATION_ERROR",
-			Message: err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
 		return
 	}
 
@@ -399,14 +628,14 @@ func (h *BrewHandler) ListSteeps(c *gin.Context) {
 // @Success 201 {object} models.Steep
 // @Failure 400 {object} models.Error
 // @Failure 404 {object} models.Error
+// @Failure 500 {object} models.Error
 // @Router /brews/{brewId}/steeps [post]
 func (h *BrewHandler) CreateSteep(c *gin.Context) {
-	brewID := c.Param("id")
-
-	if _, err := uuid.Parse(brewID); err != nil {
+	brewID, err := normalizeID(c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.Error{
 			Code:    "VALIDATION_ERROR",
-			Message: "Invalid brew ID format",
+			Message: i18n.Message(c, "Invalid brew ID format"),
 		})
 		return
 	}
@@ -415,17 +644,14 @@ func (h *BrewHandler) CreateSteep(c *gin.Context) {
 	if _, found := h.store.GetBrew(brewID); !found {
 		c.JSON(http.StatusNotFound, models.Error{
 			Code:    "NOT_FOUND",
-			Message: "Brew not found",
+			Message: i18n.Message(c, "Brew not found"),
 		})
 		return
 	}
 
 	var req models.CreateSteepRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Code:    "VALIDATION_ERROR",
-			Message: err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
 		return
 	}
 
@@ -439,9 +665,12 @@ func (h *BrewHandler) CreateSteep(c *gin.Context) {
 		DurationSeconds: req.DurationSeconds,
 		Rating:          req.Rating,
 		Notes:           req.Notes,
-		CreatedAt:       time.Now().UTC(),
+		CreatedAt:       h.clock.Now(),
 	}
 
-	h.store.CreateSteep(steep)
+	if err := h.store.CreateSteep(steep); err != nil {
+		respondIDCollision(c)
+		return
+	}
 	c.JSON(http.StatusCreated, steep)
 }
@@ -408,6 +408,36 @@ func TestBrewHandler_Patch(t *testing.T) {
 				assert.Equal(t, models.BrewSteeping, response.Status)
 			},
 		},
+		{
+			name: "invalid status transition",
+			setupStore: func(t *testing.T, s *store.MemoryStore) string {
+				teapotID := createTestTeapot(t, s)
+				teaID := createTestTea(t, s)
+				id := uuid.New().String()
+				s.CreateBrew(models.Brew{
+					ID:               id,
+					TeapotID:         teapotID,
+					TeaID:            teaID,
+					Status:           models.BrewCold,
+					WaterTempCelsius: 95,
+					StartedAt:        time.Now(),
+					CreatedAt:        time.Now(),
+					UpdatedAt:        time.Now(),
+				})
+				return id
+			},
+			getID: func(id string) string { return id },
+			body: map[string]interface{}{
+				"status": "steeping",
+			},
+			expectedStatus: http.StatusConflict,
+			validate: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response models.Error
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, "INVALID_TRANSITION", response.Code)
+			},
+		},
 		{
 			name: "non-existent brew",
 			setupStore: func(t *testing.T, s *store.MemoryStore) string {
@@ -737,3 +767,458 @@ func TestBrewHandler_CreateSteep(t *testing.T) {
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestBrewHandler_ListByTea(t *testing.T) {
+	s := store.NewMemoryStore()
+	teaID := createTestTea(t, s)
+	otherTeaID := createTestTea(t, s)
+
+	teapotNames := map[string]string{}
+	var teapotIDs []string
+	for _, name := range []string{"Kyusu", "Gaiwan Pot", "Doomed Pot"} {
+		id := uuid.New().String()
+		s.CreateTeapot(models.Teapot{
+			ID:         id,
+			Name:       name,
+			Material:   models.MaterialClay,
+			CapacityMl: 300,
+			Style:      models.StyleKyusu,
+		})
+		teapotNames[id] = name
+		teapotIDs = append(teapotIDs, id)
+	}
+
+	brewTeapots := map[string]string{}
+	for i, teapotID := range teapotIDs {
+		brewID := uuid.New().String()
+		created := time.Now().Add(time.Duration(i) * time.Minute)
+		s.CreateBrew(models.Brew{
+			ID:               brewID,
+			TeapotID:         teapotID,
+			TeaID:            teaID,
+			Status:           models.BrewPreparing,
+			WaterTempCelsius: 95,
+			StartedAt:        created,
+			CreatedAt:        created,
+			UpdatedAt:        created,
+		})
+		brewTeapots[brewID] = teapotID
+	}
+	// A brew of another tea must not be listed
+	s.CreateBrew(models.Brew{
+		ID:               uuid.New().String(),
+		TeapotID:         teapotIDs[0],
+		TeaID:            otherTeaID,
+		Status:           models.BrewPreparing,
+		WaterTempCelsius: 95,
+		StartedAt:        time.Now(),
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	})
+	deletedTeapotID := teapotIDs[2]
+	require.True(t, s.DeleteTeapot(deletedTeapotID))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/teas/:id/brews", handlers.NewBrewHandler(s).ListByTea)
+
+	t.Run("expand teapot", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/teas/"+teaID+"/brews?expand=teapot", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.BrewWithTeapotListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 3, response.Pagination.Total)
+		require.Len(t, response.Data, 3)
+
+		for _, item := range response.Data {
+			teapotID := brewTeapots[item.ID]
+			require.NotEmpty(t, teapotID)
+			if teapotID == deletedTeapotID {
+				assert.Nil(t, item.Teapot)
+				continue
+			}
+			require.NotNil(t, item.Teapot)
+			assert.Equal(t, teapotID, item.Teapot.ID)
+			assert.Equal(t, teapotNames[teapotID], item.Teapot.Name)
+		}
+
+		// The deleted teapot is serialized as an explicit null
+		var raw struct {
+			Data []map[string]json.RawMessage `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &raw))
+		assert.Equal(t, "null", string(raw.Data[0]["teapot"]))
+	})
+
+	t.Run("without expand", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/teas/"+teaID+"/brews", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var raw struct {
+			Data []map[string]json.RawMessage `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &raw))
+		require.Len(t, raw.Data, 3)
+		assert.NotContains(t, raw.Data[0], "teapot")
+	})
+
+	t.Run("invalid expand", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/teas/"+teaID+"/brews?expand=tea", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertErrorResponse(t, w)
+	})
+
+	t.Run("non-existent tea", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/teas/"+uuid.New().String()+"/brews", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assertErrorResponse(t, w)
+	})
+}
+
+func TestBrewHandler_PatchFutureTimestamp(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxFutureSkew  time.Duration
+		completedAt    time.Time
+		expectedStatus int
+	}{
+		{
+			name:           "far-future completion",
+			completedAt:    time.Now().AddDate(3, 0, 0),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "slightly in the future within skew",
+			completedAt:    time.Now().Add(time.Minute),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "past completion",
+			completedAt:    time.Now().Add(-time.Hour),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "beyond default skew",
+			completedAt:    time.Now().Add(30 * time.Minute),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "within configured skew",
+			maxFutureSkew:  time.Hour,
+			completedAt:    time.Now().Add(30 * time.Minute),
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := store.NewMemoryStore()
+			brewID := uuid.New().String()
+			s.CreateBrew(models.Brew{
+				ID:               brewID,
+				TeapotID:         createTestTeapot(t, s),
+				TeaID:            createTestTea(t, s),
+				Status:           models.BrewReady,
+				WaterTempCelsius: 95,
+				StartedAt:        time.Now().Add(-2 * time.Hour),
+				CreatedAt:        time.Now().Add(-2 * time.Hour),
+				UpdatedAt:        time.Now().Add(-2 * time.Hour),
+			})
+
+			router := gin.New()
+			handler := handlers.NewBrewHandlerWithOptions(s, handlers.BrewOptions{MaxFutureSkew: tt.maxFutureSkew})
+			router.PATCH("/brews/:id", handler.Patch)
+
+			body, err := json.Marshal(map[string]any{"completedAt": tt.completedAt.UTC().Format(time.RFC3339)})
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPatch, "/brews/"+brewID, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			brew, _ := s.GetBrew(brewID)
+			if tt.expectedStatus == http.StatusOK {
+				require.NotNil(t, brew.CompletedAt)
+				return
+			}
+
+			var errResp models.Error
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+			assert.Equal(t, "FUTURE_TIMESTAMP", errResp.Code)
+			assert.Nil(t, brew.CompletedAt)
+		})
+	}
+}
+
+func TestBrewHandler_GetIncludeSteeps(t *testing.T) {
+	s := store.NewMemoryStore()
+	teapotID := createTestTeapot(t, s)
+	teaID := createTestTea(t, s)
+
+	newBrew := func(steepCount int) string {
+		brewID := uuid.New().String()
+		s.CreateBrew(models.Brew{
+			ID:               brewID,
+			TeapotID:         teapotID,
+			TeaID:            teaID,
+			Status:           models.BrewSteeping,
+			WaterTempCelsius: 95,
+			StartedAt:        time.Now(),
+			CreatedAt:        time.Now(),
+			UpdatedAt:        time.Now(),
+		})
+		// Insert in reverse so ordering comes from the steep number
+		for n := steepCount; n >= 1; n-- {
+			s.CreateSteep(models.Steep{
+				ID:              uuid.New().String(),
+				BrewID:          brewID,
+				SteepNumber:     n,
+				DurationSeconds: 30,
+				CreatedAt:       time.Now(),
+			})
+		}
+		return brewID
+	}
+	smallBrewID := newBrew(3)
+	largeBrewID := newBrew(handlers.MaxEmbeddedSteeps + 5)
+
+	router := setupBrewRouter(t, s)
+
+	get := func(path string) map[string]json.RawMessage {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var raw map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &raw))
+		return raw
+	}
+
+	t.Run("without includeSteeps", func(t *testing.T) {
+		raw := get("/brews/" + smallBrewID)
+		assert.NotContains(t, raw, "steeps")
+		assert.NotContains(t, raw, "steepsTruncated")
+	})
+
+	t.Run("steeps embedded in order", func(t *testing.T) {
+		raw := get("/brews/" + smallBrewID + "?includeSteeps=true")
+
+		var steeps []models.Steep
+		require.NoError(t, json.Unmarshal(raw["steeps"], &steeps))
+		require.Len(t, steeps, 3)
+		for i, steep := range steeps {
+			assert.Equal(t, i+1, steep.SteepNumber)
+		}
+		assert.Equal(t, "false", string(raw["steepsTruncated"]))
+	})
+
+	t.Run("steeps truncated past the limit", func(t *testing.T) {
+		raw := get("/brews/" + largeBrewID + "?includeSteeps=true")
+
+		var steeps []models.Steep
+		require.NoError(t, json.Unmarshal(raw["steeps"], &steeps))
+		require.Len(t, steeps, handlers.MaxEmbeddedSteeps)
+		assert.Equal(t, 1, steeps[0].SteepNumber)
+		assert.Equal(t, handlers.MaxEmbeddedSteeps, steeps[len(steeps)-1].SteepNumber)
+		assert.Equal(t, "true", string(raw["steepsTruncated"]))
+	})
+
+	t.Run("brew without steeps", func(t *testing.T) {
+		raw := get("/brews/" + newBrew(0) + "?includeSteeps=true")
+		assert.Equal(t, "[]", string(raw["steeps"]))
+	})
+}
+
+func TestBrewHandler_CreateRequireTemp(t *testing.T) {
+	tests := []struct {
+		name           string
+		requireTemp    bool
+		waterTemp      *int
+		expectedStatus int
+		expectedTemp   int
+	}{
+		{
+			name:           "required and missing",
+			requireTemp:    true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "required and provided",
+			requireTemp:    true,
+			waterTemp:      intPtr(90),
+			expectedStatus: http.StatusCreated,
+			expectedTemp:   90,
+		},
+		{
+			name:           "default falls back to tea temperature",
+			expectedStatus: http.StatusCreated,
+			expectedTemp:   95,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := store.NewMemoryStore()
+			teapotID := createTestTeapot(t, s)
+			teaID := createTestTea(t, s)
+
+			router := gin.New()
+			handler := handlers.NewBrewHandlerWithOptions(s, handlers.BrewOptions{RequireTemp: tt.requireTemp})
+			router.POST("/brews", handler.Create)
+
+			body, err := json.Marshal(models.CreateBrewRequest{
+				TeapotID:         teapotID,
+				TeaID:            teaID,
+				WaterTempCelsius: tt.waterTemp,
+			})
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/brews", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusCreated {
+				var errResp models.Error
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+				assert.Equal(t, "TEMP_REQUIRED", errResp.Code)
+
+				_, total := s.ListBrews(models.BrewQuery{PaginationQuery: models.PaginationQuery{Page: 1, Limit: 20}})
+				assert.Zero(t, total)
+				return
+			}
+
+			var brew models.Brew
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &brew))
+			assert.Equal(t, tt.expectedTemp, brew.WaterTempCelsius)
+		})
+	}
+}
+
+func TestBrewHandler_CreateFromBlend(t *testing.T) {
+	s := store.NewMemoryStore()
+	teapotID := createTestTeapot(t, s)
+	minorTeaID := createTestTea(t, s)
+	majorTeaID := createTestTea(t, s)
+
+	blendID := uuid.New().String()
+	require.NoError(t, s.CreateBlend(models.Blend{
+		ID:   blendID,
+		Name: "Breakfast Blend",
+		Components: []models.BlendComponent{
+			{TeaID: minorTeaID, Ratio: 0.3},
+			{TeaID: majorTeaID, Ratio: 0.7},
+		},
+	}))
+
+	// A blend whose primary tea has since been deleted
+	deletedTeaID := createTestTea(t, s)
+	orphanedBlendID := uuid.New().String()
+	require.NoError(t, s.CreateBlend(models.Blend{
+		ID:   orphanedBlendID,
+		Name: "Orphaned Blend",
+		Components: []models.BlendComponent{
+			{TeaID: minorTeaID, Ratio: 0.4},
+			{TeaID: deletedTeaID, Ratio: 0.6},
+		},
+	}))
+	require.True(t, s.DeleteTea(deletedTeaID))
+
+	tests := []struct {
+		name           string
+		body           map[string]any
+		expectedStatus int
+		expectedCode   string
+		expectedTeaID  string
+	}{
+		{
+			name:           "blend brews its largest component",
+			body:           map[string]any{"teapotId": teapotID, "blendId": blendID},
+			expectedStatus: http.StatusCreated,
+			expectedTeaID:  majorTeaID,
+		},
+		{
+			name:           "explicit tea wins over the blend's primary tea",
+			body:           map[string]any{"teapotId": teapotID, "blendId": blendID, "teaId": minorTeaID},
+			expectedStatus: http.StatusCreated,
+			expectedTeaID:  minorTeaID,
+		},
+		{
+			name:           "tea outside the blend",
+			body:           map[string]any{"teapotId": teapotID, "blendId": blendID, "teaId": createTestTea(t, s)},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "TEA_NOT_IN_BLEND",
+		},
+		{
+			name:           "primary tea deleted",
+			body:           map[string]any{"teapotId": teapotID, "blendId": orphanedBlendID},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "BLEND_TEA_MISSING",
+		},
+		{
+			name:           "component tea named on a blend whose primary tea is deleted",
+			body:           map[string]any{"teapotId": teapotID, "blendId": orphanedBlendID, "teaId": minorTeaID},
+			expectedStatus: http.StatusCreated,
+			expectedTeaID:  minorTeaID,
+		},
+		{
+			name:           "unknown blend",
+			body:           map[string]any{"teapotId": teapotID, "blendId": uuid.New().String()},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:           "neither tea nor blend",
+			body:           map[string]any{"teapotId": teapotID},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupBrewRouter(t, s)
+
+			body, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/brews", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusCreated {
+				assertErrorResponse(t, w)
+				var errResp models.Error
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+				assert.Equal(t, tt.expectedCode, errResp.Code)
+				return
+			}
+
+			var brew models.Brew
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &brew))
+			assert.Equal(t, tt.expectedTeaID, brew.TeaID)
+			require.NotNil(t, brew.BlendID)
+			assert.Equal(t, tt.body["blendId"], *brew.BlendID)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+)
+
+// caffeineMgPerSteep is the baseline caffeine (mg) released by one steep
+// of the tea's recommended duration
+var caffeineMgPerSteep = map[models.CaffeineLevel]float64{
+	models.CaffeineNone:   0,
+	models.CaffeineLow:    15,
+	models.CaffeineMedium: 30,
+	models.CaffeineHigh:   45,
+}
+
+const caffeineDisclaimer = "Rough estimate only; actual caffeine content varies by leaf, quantity and water."
+
+// CaffeineEstimate godoc
+// @Summary Estimate caffeine intake for a brew
+// @Description Estimate total caffeine across all steeps of a brew, scaled by steep duration against the tea's recommended time
+// @Tags brews
+// @Accept json
+// @Produce json
+// @Param brewId path string true "Brew ID" format(uuid)
+// @Success 200 {object} models.CaffeineEstimate
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /brews/{brewId}/caffeine-estimate [get]
+func (h *BrewHandler) CaffeineEstimate(c *gin.Context) {
+	brewID, err := normalizeID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{
+			Code:    "VALIDATION_ERROR",
+			Message: i18n.Message(c, "Invalid brew ID format"),
+		})
+		return
+	}
+
+	brew, found := h.store.GetBrew(brewID)
+	if !found {
+		c.JSON(http.StatusNotFound, models.Error{
+			Code:    "NOT_FOUND",
+			Message: i18n.Message(c, "Brew not found"),
+		})
+		return
+	}
+
+	tea, found := h.store.GetTea(brew.TeaID)
+	if !found {
+		c.JSON(http.StatusNotFound, models.Error{
+			Code:    "NOT_FOUND",
+			Message: i18n.Message(c, "Tea not found"),
+		})
+		return
+	}
+
+	steeps := h.store.ListAllSteepsByBrew(brewID)
+	baseline := caffeineMgPerSteep[tea.CaffeineLevel]
+
+	totalSeconds := 0
+	estimate := 0.0
+	for _, steep := range steeps {
+		totalSeconds += steep.DurationSeconds
+		if tea.SteepTimeSeconds > 0 {
+			estimate += baseline * float64(steep.DurationSeconds) / float64(tea.SteepTimeSeconds)
+		}
+	}
+
+	c.JSON(http.StatusOK, models.CaffeineEstimate{
+		BrewID:            brew.ID,
+		TeaID:             tea.ID,
+		CaffeineLevel:     tea.CaffeineLevel,
+		SteepCount:        len(steeps),
+		TotalSteepSeconds: totalSeconds,
+		EstimatedMg:       math.Round(estimate*10) / 10,
+		Disclaimer:        caffeineDisclaimer,
+	})
+}
@@ -0,0 +1,137 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/api2spec/api2spec-fixture-gin/internal/handlers"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCaffeineRouter(t *testing.T, s *store.MemoryStore) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := handlers.NewBrewHandler(s)
+	router.GET("/brews/:id/caffeine-estimate", handler.CaffeineEstimate)
+	return router
+}
+
+func TestBrewHandler_CaffeineEstimate(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupStore     func(*testing.T, *store.MemoryStore) string
+		expectedStatus int
+		expectedSteeps int
+		expectedMg     float64
+	}{
+		{
+			name: "high caffeine tea with several steeps",
+			setupStore: func(t *testing.T, s *store.MemoryStore) string {
+				teapotID := createTestTeapot(t, s)
+				teaID := createTestTea(t, s)
+				brewID := uuid.New().String()
+				s.CreateBrew(models.Brew{
+					ID:               brewID,
+					TeapotID:         teapotID,
+					TeaID:            teaID,
+					Status:           models.BrewSteeping,
+					WaterTempCelsius: 95,
+					StartedAt:        time.Now(),
+					CreatedAt:        time.Now(),
+					UpdatedAt:        time.Now(),
+				})
+				for i, duration := range []int{240, 120, 480} {
+					s.CreateSteep(models.Steep{
+						ID:              uuid.New().String(),
+						BrewID:          brewID,
+						SteepNumber:     i + 1,
+						DurationSeconds: duration,
+						CreatedAt:       time.Now(),
+					})
+				}
+				return brewID
+			},
+			expectedStatus: http.StatusOK,
+			expectedSteeps: 3,
+			// 45mg per 240s recommended steep, 840s total
+			expectedMg: 157.5,
+		},
+		{
+			name: "caffeine-free tea",
+			setupStore: func(t *testing.T, s *store.MemoryStore) string {
+				teapotID := createTestTeapot(t, s)
+				teaID := uuid.New().String()
+				s.CreateTea(models.Tea{
+					ID:               teaID,
+					Name:             "Chamomile",
+					Type:             models.TeaHerbal,
+					CaffeineLevel:    models.CaffeineNone,
+					SteepTempCelsius: 100,
+					SteepTimeSeconds: 300,
+				})
+				brewID := uuid.New().String()
+				s.CreateBrew(models.Brew{
+					ID:               brewID,
+					TeapotID:         teapotID,
+					TeaID:            teaID,
+					Status:           models.BrewSteeping,
+					WaterTempCelsius: 100,
+					StartedAt:        time.Now(),
+					CreatedAt:        time.Now(),
+					UpdatedAt:        time.Now(),
+				})
+				s.CreateSteep(models.Steep{
+					ID:              uuid.New().String(),
+					BrewID:          brewID,
+					SteepNumber:     1,
+					DurationSeconds: 300,
+					CreatedAt:       time.Now(),
+				})
+				return brewID
+			},
+			expectedStatus: http.StatusOK,
+			expectedSteeps: 1,
+			expectedMg:     0,
+		},
+		{
+			name: "non-existent brew",
+			setupStore: func(t *testing.T, s *store.MemoryStore) string {
+				return uuid.New().String()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := store.NewMemoryStore()
+			id := tt.setupStore(t, s)
+			router := setupCaffeineRouter(t, s)
+
+			req := httptest.NewRequest(http.MethodGet, "/brews/"+id+"/caffeine-estimate", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var response models.CaffeineEstimate
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedSteeps, response.SteepCount)
+				assert.InDelta(t, tt.expectedMg, response.EstimatedMg, 0.01)
+				assert.NotEmpty(t, response.Disclaimer)
+			}
+		})
+	}
+}
@@ -5,15 +5,28 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
 	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/pagination"
+	"github.com/api2spec/api2spec-fixture-gin/internal/ring"
 )
 
+// DefaultHealthHistorySize is the number of readiness results kept by default
+const DefaultHealthHistorySize = 100
+
 // HealthHandler handles health check endpoints
-type HealthHandler struct{}
+type HealthHandler struct {
+	history *ring.Buffer[models.HealthHistoryEntry]
+}
 
 // NewHealthHandler creates a new health handler
 func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+	return NewHealthHandlerWithHistory(DefaultHealthHistorySize)
+}
+
+// NewHealthHandlerWithHistory creates a health handler that keeps the last size readiness results
+func NewHealthHandlerWithHistory(size int) *HealthHandler {
+	return &HealthHandler{history: ring.New[models.HealthHistoryEntry](size)}
 }
 
 // Health godoc
@@ -75,13 +88,61 @@ func (h *HealthHandler) Ready(c *gin.Context) {
 		statusCode = http.StatusServiceUnavailable
 	}
 
+	now := time.Now().UTC()
+	h.history.Add(models.HealthHistoryEntry{
+		Status:    status,
+		Timestamp: now,
+		Checks:    checks,
+	})
+
 	c.JSON(statusCode, models.HealthResponse{
 		Status:    status,
-		Timestamp: time.Now().UTC(),
+		Timestamp: now,
 		Checks:    checks,
 	})
 }
 
+// History godoc
+// @Summary Readiness check history
+// @Description Get a paginated list of recent readiness check results, newest first
+// @Tags health
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Success 200 {object} models.HealthHistoryResponse
+// @Failure 400 {object} models.Error
+// @Router /health/history [get]
+func (h *HealthHandler) History(c *gin.Context) {
+	var query models.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
+		return
+	}
+
+	// Set defaults
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 20
+	}
+
+	entries := h.history.Newest()
+	total := len(entries)
+	totalPages := (total + query.Limit - 1) / query.Limit
+
+	c.JSON(http.StatusOK, models.HealthHistoryResponse{
+		Data: pagination.Page(entries, query.Page, query.Limit),
+		Pagination: models.Pagination{
+			Page:       query.Page,
+			Limit:      query.Limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	})
+}
+
 // Brew godoc
 // @Summary TIF 418 signature endpoint
 // @Description Returns 418 I'm a teapot - TIF compliance signature
@@ -93,7 +154,7 @@ func (h *HealthHandler) Ready(c *gin.Context) {
 func (h *HealthHandler) Brew(c *gin.Context) {
 	c.JSON(http.StatusTeapot, models.TeapotResponse{
 		Error:   "I'm a teapot",
-		Message: "This server is TIF-compliant and cannot brew coffee",
+		Message: i18n.Message(c, "This server is TIF-compliant and cannot brew coffee"),
 		Spec:    "https://teapotframework.dev",
 	})
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/api2spec/api2spec-fixture-gin/internal/handlers"
@@ -99,3 +100,57 @@ func TestHealthHandler_Brew(t *testing.T) {
 	assert.Contains(t, response.Message, "TIF-compliant")
 	assert.Equal(t, "https://teapotframework.dev", response.Spec)
 }
+
+func TestHealthHandler_History(t *testing.T) {
+	handler := handlers.NewHealthHandlerWithHistory(3)
+	router := gin.New()
+	router.GET("/health/ready", handler.Ready)
+	router.GET("/health/history", handler.History)
+
+	var readyTimes []time.Time
+	for i := 0; i < 5; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/health/ready", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response models.HealthResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		readyTimes = append(readyTimes, response.Timestamp)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/health/history", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.HealthHistoryResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	// Only the last three calls are kept, newest first
+	assert.Equal(t, 3, response.Pagination.Total)
+	require.Len(t, response.Data, 3)
+	assert.True(t, response.Data[0].Timestamp.Equal(readyTimes[4]))
+	assert.True(t, response.Data[1].Timestamp.Equal(readyTimes[3]))
+	assert.True(t, response.Data[2].Timestamp.Equal(readyTimes[2]))
+	for _, entry := range response.Data {
+		assert.Equal(t, "ok", entry.Status)
+		assert.NotEmpty(t, entry.Checks)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/health/history?page=2&limit=2", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	response = models.HealthHistoryResponse{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, response.Pagination.TotalPages)
+	require.Len(t, response.Data, 1)
+	assert.True(t, response.Data[0].Timestamp.Equal(readyTimes[2]))
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+)
+
+// BeforeCreate is called with a new entity before it is stored and returns
+// the entity to store; returning an error rejects the create with a 422
+type BeforeCreate[T any] func(T) (T, error)
+
+// run applies the hook, treating a nil hook as identity. It writes the 422
+// response and returns false when the hook rejects the entity.
+func (hook BeforeCreate[T]) run(c *gin.Context, entity T) (T, bool) {
+	if hook == nil {
+		return entity, true
+	}
+	enriched, err := hook(entity)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.Error{
+			Code:    "UNPROCESSABLE_ENTITY",
+			Message: err.Error(),
+		})
+		return entity, false
+	}
+	return enriched, true
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+)
+
+var errInvalidID = errors.New("invalid ID format")
+
+// registerOnce guards RegisterValidations, which records its result in registerErr
+var (
+	registerOnce sync.Once
+	registerErr  error
+)
+
+// RegisterValidations adds the custom binding tags used by the request
+// models to gin's validator; call it before binding any of them. It is safe
+// to call more than once.
+//
+// anyuuid accepts both the hyphenated and the compact 32-hex UUID forms.
+func RegisterValidations() error {
+	registerOnce.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			registerErr = errors.New("gin binding validator is not go-playground/validator")
+			return
+		}
+		registerErr = v.RegisterValidation("anyuuid", func(fl validator.FieldLevel) bool {
+			_, err := normalizeID(fl.Field().String())
+			return err == nil
+		})
+	})
+	return registerErr
+}
+
+// normalizeID parses a hyphenated or compact (32 hex digit) UUID and
+// returns it in canonical hyphenated form
+func normalizeID(id string) (string, error) {
+	if len(id) != 36 && len(id) != 32 {
+		return "", errInvalidID
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return "", errInvalidID
+	}
+	return parsed.String(), nil
+}
+
+// canonicalizeID rewrites an optional ID in canonical form; invalid values are left untouched
+func canonicalizeID(id *string) {
+	if id == nil {
+		return
+	}
+	if normalized, err := normalizeID(*id); err == nil {
+		*id = normalized
+	}
+}
+
+// respondIDCollision reports a create whose ID is already taken; stored
+// records are never overwritten
+func respondIDCollision(c *gin.Context) {
+	c.JSON(http.StatusInternalServerError, models.Error{
+		Code:    "ID_COLLISION",
+		Message: i18n.Message(c, "A record with this ID already exists"),
+	})
+}
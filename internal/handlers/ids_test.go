@@ -0,0 +1,150 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/handlers"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactIDs(t *testing.T) {
+	s := store.NewMemoryStore()
+	teapotID := createTestTeapot(t, s)
+	teaID := createTestTea(t, s)
+	compact := strings.ReplaceAll(teapotID, "-", "")
+
+	tests := []struct {
+		name           string
+		id             string
+		expectedStatus int
+	}{
+		{
+			name:           "hyphenated form",
+			id:             teapotID,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "compact form",
+			id:             compact,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "compact form upper case",
+			id:             strings.ToUpper(compact),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "too short",
+			id:             compact[:31],
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "braced form",
+			id:             "{" + teapotID + "}",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	router := setupTeapotRouter(s)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/teapots/"+tt.id, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var response models.Teapot
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, teapotID, response.ID)
+			}
+		})
+	}
+
+	t.Run("compact IDs in brew body are stored canonically", func(t *testing.T) {
+		brewRouter := setupBrewRouter(t, s)
+
+		body, _ := json.Marshal(models.CreateBrewRequest{
+			TeapotID: compact,
+			TeaID:    strings.ReplaceAll(teaID, "-", ""),
+		})
+		req := httptest.NewRequest(http.MethodPost, "/brews", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		brewRouter.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		var response models.Brew
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, teapotID, response.TeapotID)
+		assert.Equal(t, teaID, response.TeaID)
+	})
+}
+
+func TestStore_CreateDetectsIDCollision(t *testing.T) {
+	s := store.NewMemoryStore()
+	teaID := createTestTea(t, s)
+	original, _ := s.GetTea(teaID)
+
+	err := s.CreateTea(models.Tea{
+		ID:               teaID,
+		Name:             "Impostor",
+		Type:             models.TeaGreen,
+		CaffeineLevel:    models.CaffeineLow,
+		SteepTempCelsius: 80,
+		SteepTimeSeconds: 60,
+	})
+	assert.ErrorIs(t, err, store.ErrIDCollision)
+
+	stored, _ := s.GetTea(teaID)
+	assert.Equal(t, original, stored)
+
+	teapotID := createTestTeapot(t, s)
+	_, created, err := s.CreateTeapotIfAbsent(models.Teapot{ID: teapotID, Name: "Impostor"})
+	assert.ErrorIs(t, err, store.ErrIDCollision)
+	assert.False(t, created)
+}
+
+func TestTeaHandler_CreateIDCollision(t *testing.T) {
+	s := store.NewMemoryStore()
+	existingID := createTestTea(t, s)
+
+	// Force the generated ID onto an existing one
+	handler := handlers.NewTeaHandlerWithHook(s, func(tea models.Tea) (models.Tea, error) {
+		tea.ID = existingID
+		return tea, nil
+	})
+	router := gin.New()
+	router.POST("/teas", handler.Create)
+
+	body := `{"name":"Sencha","type":"green","steepTempCelsius":80,"steepTimeSeconds":60}`
+	req := httptest.NewRequest(http.MethodPost, "/teas", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var errResp models.Error
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+	assert.Equal(t, "ID_COLLISION", errResp.Code)
+
+	stored, _ := s.GetTea(existingID)
+	assert.NotEqual(t, "Sencha", stored.Name)
+}
@@ -0,0 +1,15 @@
+package handlers_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/api2spec/api2spec-fixture-gin/internal/handlers"
+)
+
+func TestMain(m *testing.M) {
+	if err := handlers.RegisterValidations(); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ndjsonFlushEvery is how many lines are written between flushes
+const ndjsonFlushEvery = 100
+
+// writeNDJSON streams items as newline-delimited JSON, one object per line
+func writeNDJSON[T any](c *gin.Context, items []T) {
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+
+	enc := json.NewEncoder(c.Writer)
+	for i, item := range items {
+		if err := enc.Encode(item); err != nil {
+			// The client went away; nothing useful left to send
+			_ = c.Error(err)
+			return
+		}
+		if (i+1)%ndjsonFlushEvery == 0 {
+			c.Writer.Flush()
+		}
+	}
+	c.Writer.Flush()
+}
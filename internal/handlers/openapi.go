@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/spec"
+)
+
+// SpecHandler serves the generated OpenAPI specification and store schema
+type SpecHandler struct{}
+
+// NewSpecHandler creates a new spec handler
+func NewSpecHandler() *SpecHandler {
+	return &SpecHandler{}
+}
+
+// OpenAPI godoc
+// @Summary OpenAPI specification
+// @Description Get the OpenAPI 3.0 document describing this API
+// @Tags meta
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /openapi.json [get]
+func (h *SpecHandler) OpenAPI(c *gin.Context) {
+	c.JSON(http.StatusOK, spec.Document())
+}
+
+// Schema godoc
+// @Summary Store schema
+// @Description Get the fields of every stored entity with their types and validation constraints
+// @Tags meta
+// @Produce json
+// @Success 200 {object} models.SchemaResponse
+// @Router /schema [get]
+func (h *SpecHandler) Schema(c *gin.Context) {
+	c.JSON(http.StatusOK, spec.StoreSchema())
+}
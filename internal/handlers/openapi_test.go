@@ -0,0 +1,179 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/handlers"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecHandler_OpenAPI(t *testing.T) {
+	handler := handlers.NewSpecHandler()
+	router := gin.New()
+	router.GET("/openapi.json", handler.OpenAPI)
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Paths   map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]struct {
+					Enum    []string `json:"enum"`
+					Minimum *int     `json:"minimum"`
+					Maximum *int     `json:"maximum"`
+				} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &doc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	require.Contains(t, doc.Paths, "/teapots/{id}")
+	assert.Equal(t, "getTeapot", doc.Paths["/teapots/{id}"]["get"].OperationID)
+
+	createTea := doc.Components.Schemas["CreateTeaRequest"].Properties
+	require.Contains(t, createTea, "steepTempCelsius")
+	require.NotNil(t, createTea["steepTempCelsius"].Minimum)
+	assert.Equal(t, 60, *createTea["steepTempCelsius"].Minimum)
+	assert.Equal(t, 100, *createTea["steepTempCelsius"].Maximum)
+	assert.Contains(t, doc.Components.Schemas["Tea"].Properties["type"].Enum, "puerh")
+}
+
+func TestSpecHandler_OpenAPIPathParams(t *testing.T) {
+	handler := handlers.NewSpecHandler()
+	router := gin.New()
+	router.GET("/openapi.json", handler.OpenAPI)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	type param struct {
+		Name   string         `json:"name"`
+		In     string         `json:"in"`
+		Schema map[string]any `json:"schema"`
+	}
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Parameters []param `json:"parameters"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+
+	tests := []struct {
+		name           string
+		path           string
+		param          string
+		expectedFormat any
+	}{
+		{name: "entity IDs are UUIDs", path: "/teapots/{id}", param: "id", expectedFormat: "uuid"},
+		{name: "external IDs are free-form", path: "/teapots/by-external/{externalId}", param: "externalId", expectedFormat: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := doc.Paths[tt.path]["get"].Parameters
+			require.Len(t, params, 1)
+			assert.Equal(t, tt.param, params[0].Name)
+			assert.Equal(t, "path", params[0].In)
+			assert.Equal(t, "string", params[0].Schema["type"])
+			assert.Equal(t, tt.expectedFormat, params[0].Schema["format"])
+		})
+	}
+}
+
+func TestSpecHandler_Schema(t *testing.T) {
+	handler := handlers.NewSpecHandler()
+	router := gin.New()
+	router.GET("/schema", handler.Schema)
+
+	req := httptest.NewRequest(http.MethodGet, "/schema", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.SchemaResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "1.0.0", response.Version)
+
+	entities := make(map[string]map[string]models.FieldSchema)
+	for _, entity := range response.Entities {
+		fields := make(map[string]models.FieldSchema)
+		for _, f := range entity.Fields {
+			fields[f.Name] = f
+		}
+		entities[entity.Name] = fields
+	}
+	require.Contains(t, entities, "teapot")
+	require.Contains(t, entities, "brew")
+	require.Contains(t, entities, "steep")
+	require.Contains(t, entities, "tea")
+
+	tea := entities["tea"]
+	require.Contains(t, tea, "steepTempCelsius")
+	temp := tea["steepTempCelsius"]
+	assert.Equal(t, "integer", temp.Type)
+	assert.True(t, temp.Required)
+	require.NotNil(t, temp.Minimum)
+	require.NotNil(t, temp.Maximum)
+	assert.Equal(t, 60, *temp.Minimum)
+	assert.Equal(t, 100, *temp.Maximum)
+
+	assert.Contains(t, tea["type"].Enum, "rooibos")
+	assert.True(t, tea["id"].ReadOnly)
+	assert.Equal(t, "date-time", tea["createdAt"].Format)
+	assert.True(t, tea["description"].Nullable)
+	require.NotNil(t, tea["name"].MaxLength)
+	assert.Equal(t, 100, *tea["name"].MaxLength)
+	assert.Equal(t, "array", entities["brew"]["pauses"].Type)
+	assert.Equal(t, "object", entities["brew"]["pauses"].Items)
+}
+
+func TestSpecHandler_OpenAPINumericFilters(t *testing.T) {
+	handler := handlers.NewSpecHandler()
+	router := gin.New()
+	router.GET("/openapi.json", handler.OpenAPI)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Name   string         `json:"name"`
+				In     string         `json:"in"`
+				Schema map[string]any `json:"schema"`
+			} `json:"parameters"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+
+	schemas := map[string]any{}
+	for _, p := range doc.Paths["/teapots"]["get"].Parameters {
+		if p.In == "query" {
+			schemas[p.Name] = p.Schema["type"]
+		}
+	}
+	for _, op := range []string{"eq", "ne", "gt", "gte", "lt", "lte"} {
+		assert.Equal(t, "number", schemas["capacityMl["+op+"]"], op)
+	}
+}
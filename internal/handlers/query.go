@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+)
+
+// numericOps are the comparison operators accepted in field[op]=value params
+var numericOps = map[string]bool{
+	"eq": true, "ne": true, "gt": true, "gte": true, "lt": true, "lte": true,
+}
+
+// splitTeaTypes parses a comma-separated tea type filter such as
+// "green,white"; it reports false if any value is not a known type
+func splitTeaTypes(raw string) ([]models.TeaType, bool) {
+	if raw == "" {
+		return nil, true
+	}
+	var types []models.TeaType
+	for _, value := range strings.Split(raw, ",") {
+		teaType := models.TeaType(value)
+		if !slices.Contains(models.TeaTypes, teaType) {
+			return nil, false
+		}
+		types = append(types, teaType)
+	}
+	return types, true
+}
+
+// teaTypeList names every tea type for error messages, e.g. "green, black, ..."
+func teaTypeList() string {
+	names := make([]string, len(models.TeaTypes))
+	for i, t := range models.TeaTypes {
+		names[i] = string(t)
+	}
+	return strings.Join(names, ", ")
+}
+
+// parseNumericFilters collects field[op]=value query parameters into filters,
+// rejecting unknown fields, unsupported operators and non-numeric values;
+// keys are checked in sorted order so the same query always reports the same error
+func parseNumericFilters[T any](c *gin.Context, fields map[string]func(T) float64) ([]models.NumericFilter, error) {
+	query := c.Request.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var filters []models.NumericFilter
+	for _, key := range keys {
+		values := query[key]
+		field, rest, ok := strings.Cut(key, "[")
+		if !ok {
+			continue
+		}
+		op, ok := strings.CutSuffix(rest, "]")
+		if !ok {
+			return nil, errors.New(i18n.Message(c, "malformed filter parameter %q", key))
+		}
+		if _, ok := fields[field]; !ok {
+			return nil, errors.New(i18n.Message(c, "unsupported filter field %q", field))
+		}
+		if !numericOps[op] {
+			return nil, errors.New(i18n.Message(c, "unsupported filter operator %q on %s", op, field))
+		}
+		for _, raw := range values {
+			value, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, errors.New(i18n.Message(c, "filter %s must be a number", key))
+			}
+			filters = append(filters, models.NumericFilter{Field: field, Op: op, Value: value})
+		}
+	}
+	return filters, nil
+}
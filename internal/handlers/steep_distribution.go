@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+)
+
+// steepOverflowBucket is the steep count from which brews share one bucket
+const steepOverflowBucket = 10
+
+// SteepDistribution godoc
+// @Summary Distribution of steep counts
+// @Description Get a histogram of how many brews have 0, 1, 2, ... steeps, with brews of 10 or more steeps in one overflow bucket
+// @Tags brews
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.SteepDistribution
+// @Router /brews/steep-distribution [get]
+func (h *BrewHandler) SteepDistribution(c *gin.Context) {
+	histogram := h.store.SteepCountHistogram(steepOverflowBucket)
+
+	total := 0
+	buckets := make([]models.SteepCountBucket, 0, len(histogram))
+	for steeps, brews := range histogram {
+		total += brews
+		bucket := models.SteepCountBucket{
+			Label:     strconv.Itoa(steeps),
+			MinSteeps: steeps,
+			Brews:     brews,
+		}
+		if steeps == steepOverflowBucket {
+			bucket.Label += "+"
+		} else {
+			upper := steeps
+			bucket.MaxSteeps = &upper
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	c.JSON(http.StatusOK, models.SteepDistribution{
+		TotalBrews: total,
+		Buckets:    buckets,
+	})
+}
@@ -0,0 +1,78 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/api2spec/api2spec-fixture-gin/internal/handlers"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrewHandler_SteepDistribution(t *testing.T) {
+	s := store.NewMemoryStore()
+	teapotID := createTestTeapot(t, s)
+	teaID := createTestTea(t, s)
+
+	// Steep counts per brew: two with none, one with 1, two with 3,
+	// and two past the overflow bucket
+	for _, steepCount := range []int{0, 0, 1, 3, 3, 10, 14} {
+		brewID := uuid.New().String()
+		s.CreateBrew(models.Brew{
+			ID:               brewID,
+			TeapotID:         teapotID,
+			TeaID:            teaID,
+			Status:           models.BrewServed,
+			WaterTempCelsius: 95,
+			StartedAt:        time.Now(),
+			CreatedAt:        time.Now(),
+			UpdatedAt:        time.Now(),
+		})
+		for n := 1; n <= steepCount; n++ {
+			s.CreateSteep(models.Steep{
+				ID:              uuid.New().String(),
+				BrewID:          brewID,
+				SteepNumber:     n,
+				DurationSeconds: 30,
+				CreatedAt:       time.Now(),
+			})
+		}
+	}
+
+	router := gin.New()
+	router.GET("/brews/steep-distribution", handlers.NewBrewHandler(s).SteepDistribution)
+
+	req := httptest.NewRequest(http.MethodGet, "/brews/steep-distribution", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.SteepDistribution
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 7, response.TotalBrews)
+	require.Len(t, response.Buckets, 11)
+
+	counts := make(map[string]int)
+	for _, bucket := range response.Buckets {
+		counts[bucket.Label] = bucket.Brews
+	}
+	assert.Equal(t, map[string]int{
+		"0": 2, "1": 1, "2": 0, "3": 2, "4": 0, "5": 0,
+		"6": 0, "7": 0, "8": 0, "9": 0, "10+": 2,
+	}, counts)
+
+	overflow := response.Buckets[len(response.Buckets)-1]
+	assert.Equal(t, 10, overflow.MinSteeps)
+	assert.Nil(t, overflow.MaxSteeps)
+	require.NotNil(t, response.Buckets[3].MaxSteeps)
+	assert.Equal(t, 3, *response.Buckets[3].MaxSteeps)
+}
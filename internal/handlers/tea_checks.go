@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+)
+
+// tempRange is an inclusive range of sensible steeping temperatures
+type tempRange struct {
+	min, max int
+}
+
+// teaTempRanges holds the sensible steeping temperature range per tea type
+var teaTempRanges = map[models.TeaType]tempRange{
+	models.TeaGreen:   {min: 70, max: 85},
+	models.TeaWhite:   {min: 75, max: 90},
+	models.TeaOolong:  {min: 80, max: 100},
+	models.TeaBlack:   {min: 90, max: 100},
+	models.TeaPuerh:   {min: 90, max: 100},
+	models.TeaHerbal:  {min: 90, max: 100},
+	models.TeaRooibos: {min: 90, max: 100},
+}
+
+// checkTeaTemp reports in the given locale why the tea's steeping
+// temperature does not suit its type, or "" when it does
+func checkTeaTemp(locale i18n.Locale, tea models.Tea) string {
+	r, ok := teaTempRanges[tea.Type]
+	if !ok || (tea.SteepTempCelsius >= r.min && tea.SteepTempCelsius <= r.max) {
+		return ""
+	}
+	return i18n.Sprintf(locale, "Steep temperature %d°C is outside the %d-%d°C range for %s tea",
+		tea.SteepTempCelsius, r.min, r.max, tea.Type)
+}
@@ -1,18 +1,22 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
 	"github.com/api2spec/api2spec-fixture-gin/internal/models"
 	"github.com/api2spec/api2spec-fixture-gin/internal/store"
 )
 
 // TeapotHandler handles teapot-related endpoints
 type TeapotHandler struct {
-	store *store.MemoryStore
+	store        *store.MemoryStore
+	beforeCreate BeforeCreate[models.Teapot]
 }
 
 // NewTeapotHandler creates a new teapot handler
@@ -20,6 +24,11 @@ func NewTeapotHandler(store *store.MemoryStore) *TeapotHandler {
 	return &TeapotHandler{store: store}
 }
 
+// NewTeapotHandlerWithHook creates a teapot handler that runs hook on every new teapot before storing it
+func NewTeapotHandlerWithHook(store *store.MemoryStore, hook BeforeCreate[models.Teapot]) *TeapotHandler {
+	return &TeapotHandler{store: store, beforeCreate: hook}
+}
+
 // List godoc
 // @Summary List all teapots
 // @Description Get a paginated list of teapots with optional filters
@@ -28,19 +37,35 @@ func NewTeapotHandler(store *store.MemoryStore) *TeapotHandler {
 // @Produce json
 // @Param page query int false "Page number" default(1) minimum(1)
 // @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param format query string false "Response format; ndjson streams every match, one per line, ignoring page and limit" Enums(json, ndjson) default(json)
 // @Param material query string false "Filter by material" Enums(ceramic, cast-iron, glass, porcelain, clay, stainless-steel)
 // @Param style query string false "Filter by style" Enums(kyusu, gaiwan, english, moroccan, turkish, yixing)
+// @Param idle query bool false "Filter to teapots with (false) or without (true) recorded brews"
+// @Param hasDescription query bool false "Filter to teapots with (true) or without (false) a non-empty description"
+// @Param capacityMl[op] query number false "Compare capacityMl using op: eq, ne, gt, gte, lt or lte"
 // @Success 200 {object} models.TeapotListResponse
 // @Router /teapots [get]
 func (h *TeapotHandler) List(c *gin.Context) {
 	var query models.TeapotQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
+		return
+	}
+
+	numeric, err := parseNumericFilters(c, store.TeapotNumericFields)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.Error{
 			Code:    "VALIDATION_ERROR",
 			Message: err.Error(),
 		})
 		return
 	}
+	query.Numeric = numeric
+
+	if query.Format == "ndjson" {
+		writeNDJSON(c, h.store.ListAllTeapots(query))
+		return
+	}
 
 	// Set defaults
 	if query.Page == 0 {
@@ -69,21 +94,21 @@ func (h *TeapotHandler) List(c *gin.Context) {
 
 // Create godoc
 // @Summary Create a teapot
-// @Description Create a new teapot
+// @Description Create a new teapot; if externalId matches an existing teapot, that teapot is returned instead
 // @Tags teapots
 // @Accept json
 // @Produce json
 // @Param body body models.CreateTeapotRequest true "Teapot data"
+// @Success 200 {object} models.Teapot
 // @Success 201 {object} models.Teapot
 // @Failure 400 {object} models.Error
+// @Failure 422 {object} models.Error
+// @Failure 500 {object} models.Error
 // @Router /teapots [post]
 func (h *TeapotHandler) Create(c *gin.Context) {
 	var req models.CreateTeapotRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Code:    "VALIDATION_ERROR",
-			Message: err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
 		return
 	}
 
@@ -100,11 +125,26 @@ func (h *TeapotHandler) Create(c *gin.Context) {
 		CapacityMl:  req.CapacityMl,
 		Style:       req.Style,
 		Description: req.Description,
+		ExternalID:  req.ExternalID,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
 
-	h.store.CreateTeapot(teapot)
+	teapot, ok := h.beforeCreate.run(c, teapot)
+	if !ok {
+		return
+	}
+
+	// A known external ID returns the existing teapot instead of a duplicate
+	teapot, created, err := h.store.CreateTeapotIfAbsent(teapot)
+	if err != nil {
+		respondIDCollision(c)
+		return
+	}
+	if !created {
+		c.JSON(http.StatusOK, teapot)
+		return
+	}
 	c.JSON(http.StatusCreated, teapot)
 }
 
@@ -120,12 +160,11 @@ func (h *TeapotHandler) Create(c *gin.Context) {
 // @Failure 404 {object} models.Error
 // @Router /teapots/{id} [get]
 func (h *TeapotHandler) Get(c *gin.Context) {
-	id := c.Param("id")
-
-	if _, err := uuid.Parse(id); err != nil {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.Error{
 			Code:    "VALIDATION_ERROR",
-			Message: "Invalid teapot ID format",
+			Message: i18n.Message(c, "Invalid teapot ID format"),
 		})
 		return
 	}
@@ -134,7 +173,7 @@ func (h *TeapotHandler) Get(c *gin.Context) {
 	if !found {
 		c.JSON(http.StatusNotFound, models.Error{
 			Code:    "NOT_FOUND",
-			Message: "Teapot not found",
+			Message: i18n.Message(c, "Teapot not found"),
 		})
 		return
 	}
@@ -155,12 +194,11 @@ func (h *TeapotHandler) Get(c *gin.Context) {
 // @Failure 404 {object} models.Error
 // @Router /teapots/{id} [put]
 func (h *TeapotHandler) Update(c *gin.Context) {
-	id := c.Param("id")
-
-	if _, err := uuid.Parse(id); err != nil {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.Error{
 			Code:    "VALIDATION_ERROR",
-			Message: "Invalid teapot ID format",
+			Message: i18n.Message(c, "Invalid teapot ID format"),
 		})
 		return
 	}
@@ -169,17 +207,14 @@ func (h *TeapotHandler) Update(c *gin.Context) {
 	if !found {
 		c.JSON(http.StatusNotFound, models.Error{
 			Code:    "NOT_FOUND",
-			Message: "Teapot not found",
+			Message: i18n.Message(c, "Teapot not found"),
 		})
 		return
 	}
 
 	var req models.UpdateTeapotRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Code:    "VALIDATION_ERROR",
-			Message: err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
 		return
 	}
 
@@ -190,6 +225,7 @@ func (h *TeapotHandler) Update(c *gin.Context) {
 		CapacityMl:  req.CapacityMl,
 		Style:       req.Style,
 		Description: req.Description,
+		ExternalID:  existing.ExternalID,
 		CreatedAt:   existing.CreatedAt,
 		UpdatedAt:   time.Now().UTC(),
 	}
@@ -211,12 +247,11 @@ func (h *TeapotHandler) Update(c *gin.Context) {
 // @Failure 404 {object} models.Error
 // @Router /teapots/{id} [patch]
 func (h *TeapotHandler) Patch(c *gin.Context) {
-	id := c.Param("id")
-
-	if _, err := uuid.Parse(id); err != nil {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.Error{
 			Code:    "VALIDATION_ERROR",
-			Message: "Invalid teapot ID format",
+			Message: i18n.Message(c, "Invalid teapot ID format"),
 		})
 		return
 	}
@@ -225,17 +260,14 @@ func (h *TeapotHandler) Patch(c *gin.Context) {
 	if !found {
 		c.JSON(http.StatusNotFound, models.Error{
 			Code:    "NOT_FOUND",
-			Message: "Teapot not found",
+			Message: i18n.Message(c, "Teapot not found"),
 		})
 		return
 	}
 
 	var req models.PatchTeapotRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Code:    "VALIDATION_ERROR",
-			Message: err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
 		return
 	}
 
@@ -273,12 +305,11 @@ func (h *TeapotHandler) Patch(c *gin.Context) {
 // @Failure 404 {object} models.Error
 // @Router /teapots/{id} [delete]
 func (h *TeapotHandler) Delete(c *gin.Context) {
-	id := c.Param("id")
-
-	if _, err := uuid.Parse(id); err != nil {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.Error{
 			Code:    "VALIDATION_ERROR",
-			Message: "Invalid teapot ID format",
+			Message: i18n.Message(c, "Invalid teapot ID format"),
 		})
 		return
 	}
@@ -286,10 +317,150 @@ func (h *TeapotHandler) Delete(c *gin.Context) {
 	if !h.store.DeleteTeapot(id) {
 		c.JSON(http.StatusNotFound, models.Error{
 			Code:    "NOT_FOUND",
-			Message: "Teapot not found",
+			Message: i18n.Message(c, "Teapot not found"),
 		})
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
+
+// Profile godoc
+// @Summary Get a teapot's brewing profile
+// @Description Infer what a teapot is used for from the tea types of its brews
+// @Tags teapots
+// @Accept json
+// @Produce json
+// @Param id path string true "Teapot ID" format(uuid)
+// @Success 200 {object} models.TeapotProfileResponse
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Router /teapots/{id}/profile [get]
+func (h *TeapotHandler) Profile(c *gin.Context) {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{
+			Code:    "VALIDATION_ERROR",
+			Message: i18n.Message(c, "Invalid teapot ID format"),
+		})
+		return
+	}
+
+	if _, found := h.store.GetTeapot(id); !found {
+		c.JSON(http.StatusNotFound, models.Error{
+			Code:    "NOT_FOUND",
+			Message: i18n.Message(c, "Teapot not found"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.TeapotProfileResponse{
+		TeapotID: id,
+		Profile:  h.store.TeapotProfile(id),
+	})
+}
+
+// CapacityReport godoc
+// @Summary Teapot capacity report
+// @Description Get the combined and average capacity of all teapots with a per-material breakdown
+// @Tags teapots
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.CapacityReport
+// @Router /teapots/capacity-report [get]
+func (h *TeapotHandler) CapacityReport(c *gin.Context) {
+	c.JSON(http.StatusOK, h.store.TeapotCapacityReport())
+}
+
+// GetByExternalID godoc
+// @Summary Get a teapot by external ID
+// @Description Get a single teapot by the client-supplied external ID it was created with
+// @Tags teapots
+// @Accept json
+// @Produce json
+// @Param externalId path string true "External ID"
+// @Success 200 {object} models.Teapot
+// @Failure 404 {object} models.Error
+// @Router /teapots/by-external/{externalId} [get]
+func (h *TeapotHandler) GetByExternalID(c *gin.Context) {
+	teapot, found := h.store.GetTeapotByExternalID(c.Param("externalId"))
+	if !found {
+		c.JSON(http.StatusNotFound, models.Error{
+			Code:    "NOT_FOUND",
+			Message: i18n.Message(c, "Teapot not found"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, teapot)
+}
+
+// Duplicate godoc
+// @Summary Duplicate a teapot
+// @Description Create a new teapot copying material, capacity, style and description from an existing one
+// @Tags teapots
+// @Accept json
+// @Produce json
+// @Param id path string true "Source teapot ID" format(uuid)
+// @Param body body models.DuplicateTeapotRequest false "Optional overrides"
+// @Success 201 {object} models.Teapot
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 422 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /teapots/{id}/duplicate [post]
+func (h *TeapotHandler) Duplicate(c *gin.Context) {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{
+			Code:    "VALIDATION_ERROR",
+			Message: i18n.Message(c, "Invalid teapot ID format"),
+		})
+		return
+	}
+
+	source, found := h.store.GetTeapot(id)
+	if !found {
+		c.JSON(http.StatusNotFound, models.Error{
+			Code:    "NOT_FOUND",
+			Message: i18n.Message(c, "Teapot not found"),
+		})
+		return
+	}
+
+	// The body is optional; an empty one keeps the source's name
+	var req models.DuplicateTeapotRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
+		return
+	}
+
+	name := source.Name
+	if req.Name != nil {
+		name = *req.Name
+	}
+
+	// The external ID belongs to the source record, so the copy starts without one
+	now := time.Now().UTC()
+	teapot := models.Teapot{
+		ID:          uuid.New().String(),
+		Name:        name,
+		Material:    source.Material,
+		CapacityMl:  source.CapacityMl,
+		Style:       source.Style,
+		Description: source.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	teapot, ok := h.beforeCreate.run(c, teapot)
+	if !ok {
+		return
+	}
+
+	if err := h.store.CreateTeapot(teapot); err != nil {
+		respondIDCollision(c)
+		return
+	}
+	c.JSON(http.StatusCreated, teapot)
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -23,10 +24,13 @@ func setupTeapotRouter(s *store.MemoryStore) *gin.Engine {
 	handler := handlers.NewTeapotHandler(s)
 	router.GET("/teapots", handler.List)
 	router.POST("/teapots", handler.Create)
+	router.GET("/teapots/by-external/:externalId", handler.GetByExternalID)
+	router.GET("/teapots/capacity-report", handler.CapacityReport)
 	router.GET("/teapots/:id", handler.Get)
 	router.PUT("/teapots/:id", handler.Update)
 	router.PATCH("/teapots/:id", handler.Patch)
 	router.DELETE("/teapots/:id", handler.Delete)
+	router.POST("/teapots/:id/duplicate", handler.Duplicate)
 	return router
 }
 
@@ -518,3 +522,646 @@ func TestTeapotHandler_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestTeapotHandler_ListIdle(t *testing.T) {
+	s := store.NewMemoryStore()
+	teaID := createTestTea(t, s)
+
+	usedID := uuid.New().String()
+	s.CreateTeapot(models.Teapot{
+		ID:         usedID,
+		Name:       "Used Teapot",
+		Material:   models.MaterialCeramic,
+		CapacityMl: 1000,
+		Style:      models.StyleEnglish,
+	})
+	idleID := uuid.New().String()
+	s.CreateTeapot(models.Teapot{
+		ID:         idleID,
+		Name:       "Idle Teapot",
+		Material:   models.MaterialCeramic,
+		CapacityMl: 800,
+		Style:      models.StyleEnglish,
+	})
+	idleClayID := uuid.New().String()
+	s.CreateTeapot(models.Teapot{
+		ID:         idleClayID,
+		Name:       "Idle Kyusu",
+		Material:   models.MaterialClay,
+		CapacityMl: 350,
+		Style:      models.StyleKyusu,
+	})
+	s.CreateBrew(models.Brew{
+		ID:               uuid.New().String(),
+		TeapotID:         usedID,
+		TeaID:            teaID,
+		Status:           models.BrewPreparing,
+		WaterTempCelsius: 95,
+		StartedAt:        time.Now(),
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	})
+
+	assert.True(t, s.TeapotHasBrews(usedID))
+	assert.False(t, s.TeapotHasBrews(idleID))
+
+	tests := []struct {
+		name        string
+		queryParams string
+		expectedIDs []string
+	}{
+		{
+			name:        "idle teapots only",
+			queryParams: "?idle=true",
+			expectedIDs: []string{idleID, idleClayID},
+		},
+		{
+			name:        "teapots with brews only",
+			queryParams: "?idle=false",
+			expectedIDs: []string{usedID},
+		},
+		{
+			name:        "idle combined with material",
+			queryParams: "?idle=true&material=clay",
+			expectedIDs: []string{idleClayID},
+		},
+	}
+
+	router := setupTeapotRouter(s)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/teapots"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response models.TeapotListResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, teapot := range response.Data {
+				ids = append(ids, teapot.ID)
+			}
+			assert.ElementsMatch(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestTeapotHandler_ListNumericFilters(t *testing.T) {
+	s := store.NewMemoryStore()
+	capacities := map[int]string{}
+	for _, capacity := range []int{150, 350, 500, 1000} {
+		id := uuid.New().String()
+		capacities[capacity] = id
+		s.CreateTeapot(models.Teapot{
+			ID:         id,
+			Name:       "Teapot",
+			Material:   models.MaterialCeramic,
+			CapacityMl: capacity,
+			Style:      models.StyleEnglish,
+		})
+	}
+
+	tests := []struct {
+		name            string
+		queryParams     string
+		expectedStatus  int
+		expectedIDs     []string
+		expectedMessage string
+	}{
+		{
+			name:           "range",
+			queryParams:    "?capacityMl[gte]=350&capacityMl[lt]=1000",
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []string{capacities[350], capacities[500]},
+		},
+		{
+			name:           "equality",
+			queryParams:    "?capacityMl[eq]=150",
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []string{capacities[150]},
+		},
+		{
+			name:           "combined with material",
+			queryParams:    "?capacityMl[gt]=500&material=clay",
+			expectedStatus: http.StatusOK,
+			expectedIDs:    nil,
+		},
+		{
+			name:           "unsupported operator",
+			queryParams:    "?capacityMl[like]=350",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unsupported field",
+			queryParams:    "?name[eq]=1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "non-numeric value",
+			queryParams:    "?capacityMl[gte]=big",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:            "several problems report the first key in order",
+			queryParams:     "?name[eq]=1&capacityMl[like]=350",
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: `unsupported filter operator "like" on capacityMl`,
+		},
+	}
+
+	router := setupTeapotRouter(s)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/teapots"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				assertErrorResponse(t, w)
+				if tt.expectedMessage != "" {
+					var errResp models.Error
+					require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+					assert.Equal(t, tt.expectedMessage, errResp.Message)
+				}
+				return
+			}
+
+			var response models.TeapotListResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, teapot := range response.Data {
+				ids = append(ids, teapot.ID)
+			}
+			assert.ElementsMatch(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestTeapotHandler_Profile(t *testing.T) {
+	s := store.NewMemoryStore()
+	teapotID := createTestTeapot(t, s)
+	idleTeapotID := createTestTeapot(t, s)
+
+	teaIDs := map[models.TeaType]string{}
+	for _, teaType := range []models.TeaType{models.TeaOolong, models.TeaGreen} {
+		id := uuid.New().String()
+		s.CreateTea(models.Tea{
+			ID:               id,
+			Name:             string(teaType),
+			Type:             teaType,
+			CaffeineLevel:    models.CaffeineMedium,
+			SteepTempCelsius: 90,
+			SteepTimeSeconds: 60,
+		})
+		teaIDs[teaType] = id
+	}
+	for _, teaType := range []models.TeaType{models.TeaOolong, models.TeaOolong, models.TeaOolong, models.TeaGreen} {
+		s.CreateBrew(models.Brew{
+			ID:               uuid.New().String(),
+			TeapotID:         teapotID,
+			TeaID:            teaIDs[teaType],
+			Status:           models.BrewServed,
+			WaterTempCelsius: 90,
+			StartedAt:        time.Now(),
+			CreatedAt:        time.Now(),
+			UpdatedAt:        time.Now(),
+		})
+	}
+
+	router := gin.New()
+	router.GET("/teapots/:id/profile", handlers.NewTeapotHandler(s).Profile)
+
+	tests := []struct {
+		name           string
+		id             string
+		expectedStatus int
+		check          func(t *testing.T, response models.TeapotProfileResponse)
+	}{
+		{
+			name:           "teapot favoring oolong",
+			id:             teapotID,
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, response models.TeapotProfileResponse) {
+				require.NotNil(t, response.Profile)
+				assert.Equal(t, models.TeaOolong, response.Profile.PreferredType)
+				assert.Equal(t, 4, response.Profile.TotalBrews)
+				assert.Equal(t, []models.TeaTypeShare{
+					{Type: models.TeaOolong, Count: 3, Share: 0.75},
+					{Type: models.TeaGreen, Count: 1, Share: 0.25},
+				}, response.Profile.Distribution)
+			},
+		},
+		{
+			name:           "teapot without brews",
+			id:             idleTeapotID,
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, response models.TeapotProfileResponse) {
+				assert.Equal(t, idleTeapotID, response.TeapotID)
+				assert.Nil(t, response.Profile)
+			},
+		},
+		{
+			name:           "non-existent teapot",
+			id:             uuid.New().String(),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid ID",
+			id:             "not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/teapots/"+tt.id+"/profile", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.check == nil {
+				assertErrorResponse(t, w)
+				return
+			}
+
+			var response models.TeapotProfileResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			tt.check(t, response)
+		})
+	}
+}
+
+func TestTeapotHandler_ListPageBeyondEnd(t *testing.T) {
+	s := store.NewMemoryStore()
+	for i := 0; i < 3; i++ {
+		createTestTeapot(t, s)
+	}
+	router := setupTeapotRouter(s)
+
+	tests := []struct {
+		name        string
+		queryParams string
+	}{
+		{
+			name:        "high page number",
+			queryParams: "?page=10000000",
+		},
+		{
+			name:        "page number that would overflow the offset",
+			queryParams: "?page=9223372036854775807&limit=100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/teapots"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response models.TeapotListResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Empty(t, response.Data)
+			assert.NotNil(t, response.Data)
+			assert.Equal(t, 3, response.Pagination.Total)
+			assert.Equal(t, 1, response.Pagination.TotalPages)
+		})
+	}
+}
+
+func TestTeapotHandler_CreateWithExternalID(t *testing.T) {
+	s := store.NewMemoryStore()
+	router := setupTeapotRouter(s)
+
+	post := func(body string) (int, models.Teapot) {
+		req := httptest.NewRequest(http.MethodPost, "/teapots", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var teapot models.Teapot
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &teapot))
+		return w.Code, teapot
+	}
+
+	status, first := post(`{"name":"Catalog Kyusu","material":"clay","capacityMl":350,"externalId":"catalog-1"}`)
+	assert.Equal(t, http.StatusCreated, status)
+	require.NotNil(t, first.ExternalID)
+	assert.Equal(t, "catalog-1", *first.ExternalID)
+
+	// Same external ID returns the existing teapot, ignoring the new fields
+	status, second := post(`{"name":"Renamed Kyusu","material":"glass","capacityMl":500,"externalId":"catalog-1"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, "Catalog Kyusu", second.Name)
+
+	status, other := post(`{"name":"Catalog Gaiwan","material":"porcelain","capacityMl":150,"externalId":"catalog-2"}`)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.NotEqual(t, first.ID, other.ID)
+
+	// Without an external ID every create is new
+	status, plain1 := post(`{"name":"Plain","material":"ceramic","capacityMl":800}`)
+	assert.Equal(t, http.StatusCreated, status)
+	status, plain2 := post(`{"name":"Plain","material":"ceramic","capacityMl":800}`)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.NotEqual(t, plain1.ID, plain2.ID)
+
+	_, total := s.ListTeapots(models.TeapotQuery{PaginationQuery: models.PaginationQuery{Page: 1, Limit: 20}})
+	assert.Equal(t, 4, total)
+}
+
+func TestTeapotHandler_GetByExternalID(t *testing.T) {
+	s := store.NewMemoryStore()
+	router := setupTeapotRouter(s)
+
+	externalID := "catalog-42"
+	teapotID := uuid.New().String()
+	s.CreateTeapot(models.Teapot{
+		ID:         teapotID,
+		Name:       "Synced Teapot",
+		Material:   models.MaterialCeramic,
+		CapacityMl: 900,
+		Style:      models.StyleEnglish,
+		ExternalID: &externalID,
+	})
+
+	tests := []struct {
+		name           string
+		externalID     string
+		expectedStatus int
+	}{
+		{
+			name:           "known external ID",
+			externalID:     externalID,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown external ID",
+			externalID:     "catalog-404",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/teapots/by-external/"+tt.externalID, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				assertErrorResponse(t, w)
+				return
+			}
+
+			var teapot models.Teapot
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &teapot))
+			assert.Equal(t, teapotID, teapot.ID)
+		})
+	}
+
+	// Deleting the teapot frees its external ID
+	require.True(t, s.DeleteTeapot(teapotID))
+	_, found := s.GetTeapotByExternalID(externalID)
+	assert.False(t, found)
+}
+
+func TestTeapotHandler_Duplicate(t *testing.T) {
+	s := store.NewMemoryStore()
+	router := setupTeapotRouter(s)
+
+	description := "Shop display model"
+	externalID := "catalog-7"
+	sourceID := uuid.New().String()
+	s.CreateTeapot(models.Teapot{
+		ID:          sourceID,
+		Name:        "Tokoname Kyusu",
+		Material:    models.MaterialClay,
+		CapacityMl:  300,
+		Style:       models.StyleKyusu,
+		Description: &description,
+		ExternalID:  &externalID,
+		CreatedAt:   time.Now().Add(-24 * time.Hour),
+		UpdatedAt:   time.Now().Add(-24 * time.Hour),
+	})
+
+	tests := []struct {
+		name           string
+		teapotID       string
+		body           string
+		expectedStatus int
+		expectedName   string
+	}{
+		{
+			name:           "no body keeps the name",
+			teapotID:       sourceID,
+			body:           "",
+			expectedStatus: http.StatusCreated,
+			expectedName:   "Tokoname Kyusu",
+		},
+		{
+			name:           "name override",
+			teapotID:       sourceID,
+			body:           `{"name":"Tokoname Kyusu #2"}`,
+			expectedStatus: http.StatusCreated,
+			expectedName:   "Tokoname Kyusu #2",
+		},
+		{
+			name:           "empty name override",
+			teapotID:       sourceID,
+			body:           `{"name":""}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown source",
+			teapotID:       uuid.New().String(),
+			body:           "",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid ID",
+			teapotID:       "not-a-uuid",
+			body:           "",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/teapots/"+tt.teapotID+"/duplicate", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusCreated {
+				assertErrorResponse(t, w)
+				return
+			}
+
+			var teapot models.Teapot
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &teapot))
+			assert.NotEqual(t, sourceID, teapot.ID)
+			assert.Equal(t, tt.expectedName, teapot.Name)
+			assert.Equal(t, models.MaterialClay, teapot.Material)
+			assert.Equal(t, 300, teapot.CapacityMl)
+			assert.Equal(t, models.StyleKyusu, teapot.Style)
+			require.NotNil(t, teapot.Description)
+			assert.Equal(t, description, *teapot.Description)
+			assert.Nil(t, teapot.ExternalID)
+			assert.WithinDuration(t, time.Now(), teapot.CreatedAt, time.Minute)
+
+			_, found := s.GetTeapot(teapot.ID)
+			assert.True(t, found)
+		})
+	}
+
+	// The source keeps its external ID
+	teapot, found := s.GetTeapotByExternalID(externalID)
+	require.True(t, found)
+	assert.Equal(t, sourceID, teapot.ID)
+}
+
+func TestTeapotHandler_ListHasDescription(t *testing.T) {
+	s := store.NewMemoryStore()
+
+	described := "Hand-thrown in Tokoname"
+	empty := ""
+	ids := make(map[string]string)
+	for name, description := range map[string]*string{
+		"described": &described,
+		"empty":     &empty,
+		"missing":   nil,
+	} {
+		ids[name] = uuid.New().String()
+		s.CreateTeapot(models.Teapot{
+			ID:          ids[name],
+			Name:        name,
+			Material:    models.MaterialClay,
+			CapacityMl:  350,
+			Style:       models.StyleKyusu,
+			Description: description,
+		})
+	}
+
+	tests := []struct {
+		name           string
+		queryParams    string
+		expectedStatus int
+		expectedIDs    []string
+	}{
+		{
+			name:           "with description",
+			queryParams:    "?hasDescription=true",
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []string{ids["described"]},
+		},
+		{
+			name:           "without description",
+			queryParams:    "?hasDescription=false",
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []string{ids["empty"], ids["missing"]},
+		},
+		{
+			name:           "unset",
+			queryParams:    "",
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []string{ids["described"], ids["empty"], ids["missing"]},
+		},
+		{
+			name:           "invalid boolean",
+			queryParams:    "?hasDescription=maybe",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	router := setupTeapotRouter(s)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/teapots"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				assertErrorResponse(t, w)
+				return
+			}
+
+			var response models.TeapotListResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			var got []string
+			for _, teapot := range response.Data {
+				got = append(got, teapot.ID)
+			}
+			assert.ElementsMatch(t, tt.expectedIDs, got)
+		})
+	}
+}
+
+func TestTeapotHandler_CapacityReport(t *testing.T) {
+	s := store.NewMemoryStore()
+	router := setupTeapotRouter(s)
+
+	get := func() models.CapacityReport {
+		req := httptest.NewRequest(http.MethodGet, "/teapots/capacity-report", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var report models.CapacityReport
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		return report
+	}
+
+	empty := get()
+	assert.Zero(t, empty.TeapotCount)
+	assert.Zero(t, empty.AverageCapacityMl)
+	assert.NotNil(t, empty.ByMaterial)
+	assert.Empty(t, empty.ByMaterial)
+
+	for _, teapot := range []struct {
+		material models.TeapotMaterial
+		capacity int
+	}{
+		{models.MaterialClay, 300},
+		{models.MaterialClay, 350},
+		{models.MaterialGlass, 600},
+		{models.MaterialCastIron, 1200},
+	} {
+		s.CreateTeapot(models.Teapot{
+			ID:         uuid.New().String(),
+			Name:       fmt.Sprintf("%s %dml", teapot.material, teapot.capacity),
+			Material:   teapot.material,
+			CapacityMl: teapot.capacity,
+			Style:      models.StyleKyusu,
+		})
+	}
+
+	report := get()
+	assert.Equal(t, 4, report.TeapotCount)
+	assert.Equal(t, 2450, report.TotalCapacityMl)
+	assert.Equal(t, 612.5, report.AverageCapacityMl)
+	assert.Equal(t, []models.MaterialCapacity{
+		{Material: models.MaterialCastIron, Count: 1, TotalCapacityMl: 1200},
+		{Material: models.MaterialClay, Count: 2, TotalCapacityMl: 650},
+		{Material: models.MaterialGlass, Count: 1, TotalCapacityMl: 600},
+	}, report.ByMaterial)
+}
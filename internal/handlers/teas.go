@@ -1,18 +1,21 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
 	"github.com/api2spec/api2spec-fixture-gin/internal/models"
 	"github.com/api2spec/api2spec-fixture-gin/internal/store"
 )
 
 // TeaHandler handles tea-related endpoints
 type TeaHandler struct {
-	store *store.MemoryStore
+	store        *store.MemoryStore
+	beforeCreate BeforeCreate[models.Tea]
 }
 
 // NewTeaHandler creates a new tea handler
@@ -20,6 +23,11 @@ func NewTeaHandler(store *store.MemoryStore) *TeaHandler {
 	return &TeaHandler{store: store}
 }
 
+// NewTeaHandlerWithHook creates a tea handler that runs hook on every new tea before storing it
+func NewTeaHandlerWithHook(store *store.MemoryStore, hook BeforeCreate[models.Tea]) *TeaHandler {
+	return &TeaHandler{store: store, beforeCreate: hook}
+}
+
 // List godoc
 // @Summary List all teas
 // @Description Get a paginated list of teas with optional filters
@@ -28,19 +36,33 @@ func NewTeaHandler(store *store.MemoryStore) *TeaHandler {
 // @Produce json
 // @Param page query int false "Page number" default(1) minimum(1)
 // @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
-// @Param type query string false "Filter by tea type" Enums(green, black, oolong, white, puerh, herbal, rooibos)
+// @Param format query string false "Response format; ndjson streams every match, one per line, ignoring page and limit" Enums(json, ndjson) default(json)
+// @Param type query string false "Filter by tea type; comma-separated values (green, black, oolong, white, puerh, herbal, rooibos) match any"
 // @Param caffeineLevel query string false "Filter by caffeine level" Enums(none, low, medium, high)
+// @Param hasDescription query bool false "Filter to teas with (true) or without (false) a non-empty description"
 // @Success 200 {object} models.TeaListResponse
 // @Router /teas [get]
 func (h *TeaHandler) List(c *gin.Context) {
 	var query models.TeaQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
+		return
+	}
+
+	types, ok := splitTeaTypes(query.Type)
+	if !ok {
 		c.JSON(http.StatusBadRequest, models.Error{
 			Code:    "VALIDATION_ERROR",
-			Message: err.Error(),
+			Message: i18n.Message(c, "%s must be one of: %s", "type", teaTypeList()),
 		})
 		return
 	}
+	query.Types = types
+
+	if query.Format == "ndjson" {
+		writeNDJSON(c, h.store.ListAllTeas(query))
+		return
+	}
 
 	// Set defaults
 	if query.Page == 0 {
@@ -76,22 +98,16 @@ func (h *TeaHandler) List(c *gin.Context) {
 // @Param body body models.CreateTeaRequest true "Tea data"
 // @Success 201 {object} models.Tea
 // @Failure 400 {object} models.Error
+// @Failure 422 {object} models.Error
+// @Failure 500 {object} models.Error
 // @Router /teas [post]
 func (h *TeaHandler) Create(c *gin.Context) {
 	var req models.CreateTeaRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Code:    "VALIDATION_ERROR",
-			Message: err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
 		return
 	}
 
-	// Set default caffeine level if not provided
-	if req.CaffeineLevel == "" {
-		req.CaffeineLevel = models.CaffeineMedium
-	}
-
 	now := time.Now().UTC()
 	tea := models.Tea{
 		ID:               uuid.New().String(),
@@ -106,7 +122,21 @@ func (h *TeaHandler) Create(c *gin.Context) {
 		UpdatedAt:        now,
 	}
 
-	h.store.CreateTea(tea)
+	// The hook sees an empty caffeine level when the client omitted it
+	tea, ok := h.beforeCreate.run(c, tea)
+	if !ok {
+		return
+	}
+
+	// Set default caffeine level if neither the client nor the hook did
+	if tea.CaffeineLevel == "" {
+		tea.CaffeineLevel = models.CaffeineMedium
+	}
+
+	if err := h.store.CreateTea(tea); err != nil {
+		respondIDCollision(c)
+		return
+	}
 	c.JSON(http.StatusCreated, tea)
 }
 
@@ -122,12 +152,11 @@ func (h *TeaHandler) Create(c *gin.Context) {
 // @Failure 404 {object} models.Error
 // @Router /teas/{id} [get]
 func (h *TeaHandler) Get(c *gin.Context) {
-	id := c.Param("id")
-
-	if _, err := uuid.Parse(id); err != nil {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.Error{
 			Code:    "VALIDATION_ERROR",
-			Message: "Invalid tea ID format",
+			Message: i18n.Message(c, "Invalid tea ID format"),
 		})
 		return
 	}
@@ -136,7 +165,7 @@ func (h *TeaHandler) Get(c *gin.Context) {
 	if !found {
 		c.JSON(http.StatusNotFound, models.Error{
 			Code:    "NOT_FOUND",
-			Message: "Tea not found",
+			Message: i18n.Message(c, "Tea not found"),
 		})
 		return
 	}
@@ -157,12 +186,11 @@ func (h *TeaHandler) Get(c *gin.Context) {
 // @Failure 404 {object} models.Error
 // @Router /teas/{id} [put]
 func (h *TeaHandler) Update(c *gin.Context) {
-	id := c.Param("id")
-
-	if _, err := uuid.Parse(id); err != nil {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.Error{
 			Code:    "VALIDATION_ERROR",
-			Message: "Invalid tea ID format",
+			Message: i18n.Message(c, "Invalid tea ID format"),
 		})
 		return
 	}
@@ -171,17 +199,14 @@ func (h *TeaHandler) Update(c *gin.Context) {
 	if !found {
 		c.JSON(http.StatusNotFound, models.Error{
 			Code:    "NOT_FOUND",
-			Message: "Tea not found",
+			Message: i18n.Message(c, "Tea not found"),
 		})
 		return
 	}
 
 	var req models.UpdateTeaRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Code:    "VALIDATION_ERROR",
-			Message: err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
 		return
 	}
 
@@ -209,18 +234,19 @@ func (h *TeaHandler) Update(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Tea ID" format(uuid)
+// @Param strict query bool false "Reject a type change whose temperature no longer suits the type instead of warning"
 // @Param body body models.PatchTeaRequest true "Fields to update"
-// @Success 200 {object} models.Tea
+// @Success 200 {object} models.PatchTeaResponse
 // @Failure 400 {object} models.Error
 // @Failure 404 {object} models.Error
+// @Failure 422 {object} models.Error
 // @Router /teas/{id} [patch]
 func (h *TeaHandler) Patch(c *gin.Context) {
-	id := c.Param("id")
-
-	if _, err := uuid.Parse(id); err != nil {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.Error{
 			Code:    "VALIDATION_ERROR",
-			Message: "Invalid tea ID format",
+			Message: i18n.Message(c, "Invalid tea ID format"),
 		})
 		return
 	}
@@ -229,46 +255,39 @@ func (h *TeaHandler) Patch(c *gin.Context) {
 	if !found {
 		c.JSON(http.StatusNotFound, models.Error{
 			Code:    "NOT_FOUND",
-			Message: "Tea not found",
+			Message: i18n.Message(c, "Tea not found"),
 		})
 		return
 	}
 
 	var req models.PatchTeaRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Code:    "VALIDATION_ERROR",
-			Message: err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
 		return
 	}
 
-	// Apply patches
-	if req.Name != nil {
-		existing.Name = *req.Name
-	}
-	if req.Type != nil {
-		existing.Type = *req.Type
-	}
-	if req.Origin != nil {
-		existing.Origin = req.Origin
-	}
-	if req.CaffeineLevel != nil {
-		existing.CaffeineLevel = *req.CaffeineLevel
-	}
-	if req.SteepTempCelsius != nil {
-		existing.SteepTempCelsius = *req.SteepTempCelsius
-	}
-	if req.SteepTimeSeconds != nil {
-		existing.SteepTimeSeconds = *req.SteepTimeSeconds
-	}
-	if req.Description != nil {
-		existing.Description = req.Description
+	typeChanged := req.Type != nil && *req.Type != existing.Type
+	existing = applyTeaPatch(existing, req)
+
+	// A new type can turn the stored temperature into bad advice
+	var warnings []models.TeaWarning
+	if typeChanged {
+		if problem := checkTeaTemp(i18n.FromContext(c), existing); problem != "" {
+			if c.Query("strict") == "true" {
+				c.JSON(http.StatusUnprocessableEntity, models.Error{
+					Code:    "TEMP_TYPE_MISMATCH",
+					Message: problem,
+				})
+				return
+			}
+			warnings = append(warnings, models.TeaWarning{ID: existing.ID, Message: problem})
+		}
 	}
+
 	existing.UpdatedAt = time.Now().UTC()
 
 	h.store.UpdateTea(existing)
-	c.JSON(http.StatusOK, existing)
+	c.JSON(http.StatusOK, models.PatchTeaResponse{Tea: existing, Warnings: warnings})
 }
 
 // Delete godoc
@@ -283,12 +302,11 @@ func (h *TeaHandler) Patch(c *gin.Context) {
 // @Failure 404 {object} models.Error
 // @Router /teas/{id} [delete]
 func (h *TeaHandler) Delete(c *gin.Context) {
-	id := c.Param("id")
-
-	if _, err := uuid.Parse(id); err != nil {
+	id, err := normalizeID(c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.Error{
 			Code:    "VALIDATION_ERROR",
-			Message: "Invalid tea ID format",
+			Message: i18n.Message(c, "Invalid tea ID format"),
 		})
 		return
 	}
@@ -296,10 +314,112 @@ func (h *TeaHandler) Delete(c *gin.Context) {
 	if !h.store.DeleteTea(id) {
 		c.JSON(http.StatusNotFound, models.Error{
 			Code:    "NOT_FOUND",
-			Message: "Tea not found",
+			Message: i18n.Message(c, "Tea not found"),
 		})
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
+
+// BulkPatch godoc
+// @Summary Patch all teas matching a filter
+// @Description Apply one partial update to every tea matching the filter; an empty filter matches every tea, so confirm=true is required. A type change is checked against each tea's temperature like a single patch.
+// @Tags teas
+// @Accept json
+// @Produce json
+// @Param confirm query bool true "Must be true to apply the patch"
+// @Param strict query bool false "Reject the whole patch if a type change leaves any tea's temperature outside the type's range instead of warning"
+// @Param body body models.BulkPatchTeasRequest true "Filter and fields to update"
+// @Success 200 {object} models.BulkPatchResult
+// @Failure 400 {object} models.Error
+// @Failure 422 {object} models.Error
+// @Router /teas/bulk-patch [post]
+func (h *TeaHandler) BulkPatch(c *gin.Context) {
+	if c.Query("confirm") != "true" {
+		c.JSON(http.StatusBadRequest, models.Error{
+			Code:    "CONFIRMATION_REQUIRED",
+			Message: i18n.Message(c, "Bulk patch requires confirm=true"),
+		})
+		return
+	}
+
+	var req models.BulkPatchTeasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, bindingError(c, err))
+		return
+	}
+
+	if req.Patch.IsEmpty() {
+		c.JSON(http.StatusBadRequest, models.Error{
+			Code:    "VALIDATION_ERROR",
+			Message: i18n.Message(c, "patch must set at least one field"),
+		})
+		return
+	}
+
+	query := models.TeaQuery{CaffeineLevel: req.Filter.CaffeineLevel}
+	if req.Filter.Type != nil {
+		query.Types = []models.TeaType{*req.Filter.Type}
+	}
+
+	locale := i18n.FromContext(c)
+	strict := c.Query("strict") == "true"
+	now := time.Now().UTC()
+	var warnings []models.TeaWarning
+	ids, err := h.store.UpdateTeasWhere(query, func(tea models.Tea) (models.Tea, error) {
+		typeChanged := req.Patch.Type != nil && *req.Patch.Type != tea.Type
+		tea = applyTeaPatch(tea, req.Patch)
+		tea.UpdatedAt = now
+
+		// A new type can turn the stored temperature into bad advice
+		if typeChanged {
+			if problem := checkTeaTemp(locale, tea); problem != "" {
+				if strict {
+					return tea, errors.New(problem)
+				}
+				warnings = append(warnings, models.TeaWarning{ID: tea.ID, Message: problem})
+			}
+		}
+		return tea, nil
+	})
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.Error{
+			Code:    "TEMP_TYPE_MISMATCH",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BulkPatchResult{
+		Updated:  len(ids),
+		IDs:      ids,
+		Warnings: warnings,
+	})
+}
+
+// applyTeaPatch copies the fields set in req onto tea
+func applyTeaPatch(tea models.Tea, req models.PatchTeaRequest) models.Tea {
+	if req.Name != nil {
+		tea.Name = *req.Name
+	}
+	if req.Type != nil {
+		tea.Type = *req.Type
+	}
+	if req.Origin != nil {
+		tea.Origin = req.Origin
+	}
+	if req.CaffeineLevel != nil {
+		tea.CaffeineLevel = *req.CaffeineLevel
+	}
+	if req.SteepTempCelsius != nil {
+		tea.SteepTempCelsius = *req.SteepTempCelsius
+	}
+	if req.SteepTimeSeconds != nil {
+		tea.SteepTimeSeconds = *req.SteepTimeSeconds
+	}
+	if req.Description != nil {
+		tea.Description = req.Description
+	}
+	return tea
+}
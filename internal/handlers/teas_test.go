@@ -3,8 +3,10 @@ package handlers_test
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -133,6 +135,74 @@ func TestTeaHandler_List(t *testing.T) {
 	}
 }
 
+func TestTeaHandler_ListMultipleTypes(t *testing.T) {
+	s := store.NewMemoryStore()
+	for _, teaType := range []models.TeaType{models.TeaGreen, models.TeaWhite, models.TeaOolong, models.TeaBlack, models.TeaPuerh} {
+		s.CreateTea(models.Tea{
+			ID:               uuid.New().String(),
+			Name:             string(teaType) + " tea",
+			Type:             teaType,
+			CaffeineLevel:    models.CaffeineMedium,
+			SteepTempCelsius: 85,
+			SteepTimeSeconds: 120,
+		})
+	}
+	router := setupTeaRouter(s)
+
+	tests := []struct {
+		name           string
+		queryParams    string
+		expectedStatus int
+		expectedTypes  []models.TeaType
+	}{
+		{
+			name:           "several types",
+			queryParams:    "?type=green,white,oolong",
+			expectedStatus: http.StatusOK,
+			expectedTypes:  []models.TeaType{models.TeaGreen, models.TeaWhite, models.TeaOolong},
+		},
+		{
+			name:           "single type",
+			queryParams:    "?type=puerh",
+			expectedStatus: http.StatusOK,
+			expectedTypes:  []models.TeaType{models.TeaPuerh},
+		},
+		{
+			name:           "one invalid type in list",
+			queryParams:    "?type=green,coffee",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/teas"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			// The handler reads the filter without rewriting the request
+			assert.Equal(t, tt.queryParams[1:], req.URL.RawQuery)
+
+			if tt.expectedStatus != http.StatusOK {
+				assertErrorResponse(t, w)
+				return
+			}
+
+			var response models.TeaListResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			var types []models.TeaType
+			for _, tea := range response.Data {
+				types = append(types, tea.Type)
+			}
+			assert.ElementsMatch(t, tt.expectedTypes, types)
+		})
+	}
+}
+
 func TestTeaHandler_Create(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -471,3 +541,375 @@ func TestTeaHandler_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestTeaHandler_ListNDJSON(t *testing.T) {
+	s := store.NewMemoryStore()
+	for i, teaType := range []models.TeaType{models.TeaGreen, models.TeaGreen, models.TeaGreen, models.TeaBlack} {
+		s.CreateTea(models.Tea{
+			ID:               uuid.New().String(),
+			Name:             fmt.Sprintf("Tea %d", i),
+			Type:             teaType,
+			CaffeineLevel:    models.CaffeineMedium,
+			SteepTempCelsius: 80,
+			SteepTimeSeconds: 120,
+		})
+	}
+	router := setupTeaRouter(s)
+
+	// page and limit are ignored when streaming
+	req := httptest.NewRequest(http.MethodGet, "/teas?format=ndjson&type=green&page=2&limit=1", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+
+	lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+	require.Len(t, lines, 3)
+	for _, line := range lines {
+		var tea models.Tea
+		err := json.Unmarshal([]byte(line), &tea)
+		require.NoError(t, err)
+		assert.NotEmpty(t, tea.ID)
+		assert.Equal(t, models.TeaGreen, tea.Type)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/teas?format=csv", nil)
+	w = httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTeaHandler_BulkPatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		body           interface{}
+		expectedStatus int
+		expectedCount  int
+	}{
+		{
+			name:        "filtered patch",
+			queryParams: "?confirm=true",
+			body: map[string]interface{}{
+				"filter": map[string]interface{}{"type": "green"},
+				"patch":  map[string]interface{}{"steepTempCelsius": 78},
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name:        "missing confirmation",
+			queryParams: "",
+			body: map[string]interface{}{
+				"filter": map[string]interface{}{"type": "green"},
+				"patch":  map[string]interface{}{"steepTempCelsius": 78},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "invalid patch",
+			queryParams: "?confirm=true",
+			body: map[string]interface{}{
+				"filter": map[string]interface{}{"type": "green"},
+				"patch":  map[string]interface{}{"steepTempCelsius": 20},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "empty patch",
+			queryParams: "?confirm=true",
+			body: map[string]interface{}{
+				"filter": map[string]interface{}{"type": "green"},
+				"patch":  map[string]interface{}{},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := store.NewMemoryStore()
+			ids := map[models.TeaType][]string{}
+			for _, teaType := range []models.TeaType{models.TeaGreen, models.TeaGreen, models.TeaBlack} {
+				id := uuid.New().String()
+				s.CreateTea(models.Tea{
+					ID:               id,
+					Name:             string(teaType) + " tea",
+					Type:             teaType,
+					CaffeineLevel:    models.CaffeineMedium,
+					SteepTempCelsius: 85,
+					SteepTimeSeconds: 120,
+				})
+				ids[teaType] = append(ids[teaType], id)
+			}
+			router := gin.New()
+			router.POST("/teas/bulk-patch", handlers.NewTeaHandler(s).BulkPatch)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/teas/bulk-patch"+tt.queryParams, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			expectedGreenTemp := 85
+			if tt.expectedStatus == http.StatusOK {
+				var response models.BulkPatchResult
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedCount, response.Updated)
+				assert.ElementsMatch(t, ids[models.TeaGreen], response.IDs)
+				expectedGreenTemp = 78
+			}
+
+			for _, id := range ids[models.TeaGreen] {
+				tea, _ := s.GetTea(id)
+				assert.Equal(t, expectedGreenTemp, tea.SteepTempCelsius)
+			}
+			black, _ := s.GetTea(ids[models.TeaBlack][0])
+			assert.Equal(t, 85, black.SteepTempCelsius)
+		})
+	}
+}
+
+func TestTeaHandler_BulkPatchTypeTempMismatch(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		patch            string
+		expectedStatus   int
+		expectedWarnings int
+		expectedType     models.TeaType
+	}{
+		{
+			name:             "type change invalidates temp warns by default",
+			query:            "?confirm=true",
+			patch:            `{"type":"black"}`,
+			expectedStatus:   http.StatusOK,
+			expectedWarnings: 2,
+			expectedType:     models.TeaBlack,
+		},
+		{
+			name:           "type change invalidates temp rejected in strict mode",
+			query:          "?confirm=true&strict=true",
+			patch:          `{"type":"black"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedType:   models.TeaGreen,
+		},
+		{
+			name:           "type change with fitting temp in strict mode",
+			query:          "?confirm=true&strict=true",
+			patch:          `{"type":"black","steepTempCelsius":95}`,
+			expectedStatus: http.StatusOK,
+			expectedType:   models.TeaBlack,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := store.NewMemoryStore()
+			var ids []string
+			for i := 0; i < 2; i++ {
+				id := uuid.New().String()
+				s.CreateTea(models.Tea{
+					ID:               id,
+					Name:             "Green tea",
+					Type:             models.TeaGreen,
+					CaffeineLevel:    models.CaffeineLow,
+					SteepTempCelsius: 80,
+					SteepTimeSeconds: 120,
+				})
+				ids = append(ids, id)
+			}
+			router := gin.New()
+			router.POST("/teas/bulk-patch", handlers.NewTeaHandler(s).BulkPatch)
+
+			body := `{"filter":{"type":"green"},"patch":` + tt.patch + `}`
+			req := httptest.NewRequest(http.MethodPost, "/teas/bulk-patch"+tt.query, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response models.BulkPatchResult
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, 2, response.Updated)
+				assert.Len(t, response.Warnings, tt.expectedWarnings)
+			} else {
+				var errResp models.Error
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+				assert.Equal(t, "TEMP_TYPE_MISMATCH", errResp.Code)
+			}
+
+			for _, id := range ids {
+				tea, _ := s.GetTea(id)
+				assert.Equal(t, tt.expectedType, tea.Type)
+			}
+		})
+	}
+}
+
+func TestTeaHandler_PatchTypeTempMismatch(t *testing.T) {
+	tests := []struct {
+		name            string
+		query           string
+		body            string
+		expectedStatus  int
+		expectedWarning bool
+		expectedType    models.TeaType
+	}{
+		{
+			name:            "type change invalidates temp warns by default",
+			body:            `{"type":"green"}`,
+			expectedStatus:  http.StatusOK,
+			expectedWarning: true,
+			expectedType:    models.TeaGreen,
+		},
+		{
+			name:           "type change invalidates temp rejected in strict mode",
+			query:          "?strict=true",
+			body:           `{"type":"green"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedType:   models.TeaBlack,
+		},
+		{
+			name:           "type change with fitting temp in strict mode",
+			query:          "?strict=true",
+			body:           `{"type":"green","steepTempCelsius":80}`,
+			expectedStatus: http.StatusOK,
+			expectedType:   models.TeaGreen,
+		},
+		{
+			name:           "temp change without type change is not checked",
+			query:          "?strict=true",
+			body:           `{"steepTempCelsius":60}`,
+			expectedStatus: http.StatusOK,
+			expectedType:   models.TeaBlack,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := store.NewMemoryStore()
+			teaID := createTestTea(t, s) // black tea at 95°C
+			router := setupTeaRouter(s)
+
+			req := httptest.NewRequest(http.MethodPatch, "/teas/"+teaID+tt.query, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Empty(t, w.Header().Get("Warning"))
+			if tt.expectedStatus == http.StatusOK {
+				var response models.PatchTeaResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, teaID, response.ID)
+				if tt.expectedWarning {
+					require.Len(t, response.Warnings, 1)
+					assert.Equal(t, teaID, response.Warnings[0].ID)
+					assert.Contains(t, response.Warnings[0].Message, "green")
+				} else {
+					assert.Empty(t, response.Warnings)
+				}
+			}
+			if tt.expectedStatus == http.StatusUnprocessableEntity {
+				var errResp models.Error
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+				assert.Equal(t, "TEMP_TYPE_MISMATCH", errResp.Code)
+			}
+
+			tea, _ := s.GetTea(teaID)
+			assert.Equal(t, tt.expectedType, tea.Type)
+		})
+	}
+}
+
+func TestTeaHandler_ListHasDescription(t *testing.T) {
+	s := store.NewMemoryStore()
+
+	described := "Pan-fired with a chestnut finish"
+	empty := ""
+	ids := make(map[string]string)
+	for name, description := range map[string]*string{
+		"described": &described,
+		"empty":     &empty,
+		"missing":   nil,
+	} {
+		ids[name] = uuid.New().String()
+		s.CreateTea(models.Tea{
+			ID:               ids[name],
+			Name:             name,
+			Type:             models.TeaGreen,
+			CaffeineLevel:    models.CaffeineMedium,
+			SteepTempCelsius: 80,
+			SteepTimeSeconds: 120,
+			Description:      description,
+		})
+	}
+
+	tests := []struct {
+		name           string
+		queryParams    string
+		expectedStatus int
+		expectedIDs    []string
+	}{
+		{
+			name:           "with description",
+			queryParams:    "?hasDescription=true",
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []string{ids["described"]},
+		},
+		{
+			name:           "without description",
+			queryParams:    "?hasDescription=false",
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []string{ids["empty"], ids["missing"]},
+		},
+		{
+			name:           "combined with type",
+			queryParams:    "?hasDescription=true&type=black",
+			expectedStatus: http.StatusOK,
+			expectedIDs:    nil,
+		},
+		{
+			name:           "invalid boolean",
+			queryParams:    "?hasDescription=maybe",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	router := setupTeaRouter(s)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/teas"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				assertErrorResponse(t, w)
+				return
+			}
+
+			var response models.TeaListResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			var got []string
+			for _, tea := range response.Data {
+				got = append(got, tea.ID)
+			}
+			assert.ElementsMatch(t, tt.expectedIDs, got)
+		})
+	}
+}
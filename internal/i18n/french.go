@@ -0,0 +1,61 @@
+package i18n
+
+// french holds the French translations of error and validation issue messages
+var french = map[string]string{
+	// Request parsing and validation
+	"Malformed JSON at offset %d: %s":               "JSON mal formé à la position %d : %s",
+	"Field %s must be %s, got %s":                   "Le champ %s doit être de type %s, reçu %s",
+	"Request body is empty":                         "Le corps de la requête est vide",
+	"Request body ends before the JSON is complete": "Le corps de la requête se termine avant la fin du JSON",
+	"%s is required":                                "%s est obligatoire",
+	"%s must be at least %s":                        "%s doit être au moins %s",
+	"%s must be at least %s characters":             "%s doit contenir au moins %s caractères",
+	"%s must be at least %s items":                  "%s doit contenir au moins %s éléments",
+	"%s must be at most %s":                         "%s doit être au plus %s",
+	"%s must be at most %s characters":              "%s doit contenir au plus %s caractères",
+	"%s must be at most %s items":                   "%s doit contenir au plus %s éléments",
+	"%s must be greater than %s":                    "%s doit être supérieur à %s",
+	"%s must be less than %s":                       "%s doit être inférieur à %s",
+	"%s must be one of: %s":                         "%s doit être l'une des valeurs suivantes : %s",
+	"%s must be a valid UUID":                       "%s doit être un UUID valide",
+	"%s is invalid":                                 "%s est invalide",
+	"malformed filter parameter %q":                 "paramètre de filtre mal formé %q",
+	"unsupported filter field %q":                   "champ de filtre non pris en charge %q",
+	"unsupported filter operator %q on %s":          "opérateur de filtre non pris en charge %q sur %s",
+	"filter %s must be a number":                    "le filtre %s doit être un nombre",
+
+	// Identifiers and lookups
+	"Invalid teapot ID format": "Format d'identifiant de théière invalide",
+	"Invalid tea ID format":    "Format d'identifiant de thé invalide",
+	"Invalid brew ID format":   "Format d'identifiant d'infusion invalide",
+	"Invalid blend ID format":  "Format d'identifiant de mélange invalide",
+	"Teapot not found":         "Théière introuvable",
+	"Tea not found":            "Thé introuvable",
+	"Brew not found":           "Infusion introuvable",
+	"Blend not found":          "Mélange introuvable",
+
+	// Business rules
+	"A record with this ID already exists":                                "Un enregistrement avec cet identifiant existe déjà",
+	"patch must set at least one field":                                   "patch doit modifier au moins un champ",
+	"Bulk patch requires confirm=true":                                    "La modification groupée nécessite confirm=true",
+	"Brew is already paused":                                              "L'infusion est déjà en pause",
+	"Brew is not paused":                                                  "L'infusion n'est pas en pause",
+	"Only steeping brews can be paused":                                   "Seules les infusions en cours peuvent être mises en pause",
+	"Only steeping brews can be resumed":                                  "Seules les infusions en cours peuvent être reprises",
+	"Brew was modified concurrently; try again":                           "L'infusion a été modifiée en parallèle ; réessayez",
+	"Brew cannot move from %s to %s":                                      "L'infusion ne peut pas passer de %s à %s",
+	"completedAt is more than %s in the future":                           "completedAt est plus de %s dans le futur",
+	"waterTempCelsius is required":                                        "waterTempCelsius est obligatoire",
+	"Steep temperature %d°C is outside the %d-%d°C range for %s tea":      "La température d'infusion de %d °C est hors de la plage %d-%d °C pour le thé %s",
+	"Tea %s not found":                                                    "Thé %s introuvable",
+	"Tea %s appears more than once":                                       "Le thé %s apparaît plusieurs fois",
+	"Tea %s is not a component of this blend":                             "Le thé %s ne fait pas partie de ce mélange",
+	"The blend's primary tea %s no longer exists":                         "Le thé principal %s du mélange n'existe plus",
+	"Component ratios sum to %.2f; they must sum to 1.0":                  "La somme des proportions est %.2f ; elle doit être égale à 1,0",
+	"Water temperature %d°C is %d°C away from the tea's recommended %d°C": "La température de l'eau de %d °C s'écarte de %d °C des %d °C recommandés pour ce thé",
+	"Teapot holds only %dml; at least %dml is recommended":                "La théière ne contient que %d ml ; au moins %d ml sont recommandés",
+	"%s tea is best brewed in teapots of %dml or less":                    "Le thé %s s'infuse mieux dans des théières de %d ml ou moins",
+	"Teapot is seasoned with %s tea; brewing %s tea will mix flavors":     "La théière est culottée au thé %s ; infuser du thé %s mélangera les saveurs",
+	"Missing or invalid admin token":                                      "Jeton d'administration manquant ou invalide",
+	"This server is TIF-compliant and cannot brew coffee":                 "Ce serveur est conforme TIF et ne peut pas préparer de café",
+}
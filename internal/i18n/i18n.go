@@ -0,0 +1,95 @@
+// Package i18n translates error messages. Messages are looked up by their
+// English text, which doubles as the fmt format string for messages with
+// arguments; text missing from a catalog is returned in English.
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Locale identifies a message catalog by its base language tag
+type Locale string
+
+const (
+	English Locale = "en"
+	French  Locale = "fr"
+)
+
+// Default is the locale used when none is configured or negotiated
+const Default = English
+
+// ContextKey is the gin context key holding the request's locale
+const ContextKey = "locale"
+
+// catalogs maps each non-English locale to its translations, keyed by the
+// English message
+var catalogs = map[Locale]map[string]string{
+	French: french,
+}
+
+// Parse returns the supported locale for a language tag such as "fr" or
+// "fr-CA", reporting false for unsupported languages
+func Parse(tag string) (Locale, bool) {
+	base, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	locale := Locale(strings.ToLower(base))
+	if locale == English {
+		return English, true
+	}
+	_, ok := catalogs[locale]
+	return locale, ok
+}
+
+// Negotiate picks the supported locale with the highest q-value from an
+// Accept-Language header, falling back when none is supported
+func Negotiate(acceptLanguage string, fallback Locale) Locale {
+	best, bestQ := fallback, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if locale, ok := Parse(tag); ok && q > bestQ {
+			best, bestQ = locale, q
+		}
+	}
+	return best
+}
+
+// Translate returns message in the given locale
+func Translate(locale Locale, message string) string {
+	if translated, ok := catalogs[locale][message]; ok {
+		return translated
+	}
+	return message
+}
+
+// Sprintf translates format and then formats it with args
+func Sprintf(locale Locale, format string, args ...any) string {
+	return fmt.Sprintf(Translate(locale, format), args...)
+}
+
+// Message formats a message in the request's locale; it is shorthand for
+// Sprintf(FromContext(c), format, args...)
+func Message(c *gin.Context, format string, args ...any) string {
+	return Sprintf(FromContext(c), format, args...)
+}
+
+// FromContext returns the locale chosen for the request, or Default when
+// no locale middleware ran
+func FromContext(c *gin.Context) Locale {
+	if value, ok := c.Get(ContextKey); ok {
+		if locale, ok := value.(Locale); ok {
+			return locale
+		}
+	}
+	return Default
+}
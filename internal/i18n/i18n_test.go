@@ -0,0 +1,45 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		fallback       i18n.Locale
+		expected       i18n.Locale
+	}{
+		{name: "empty header", acceptLanguage: "", fallback: i18n.English, expected: i18n.English},
+		{name: "empty header with French fallback", acceptLanguage: "", fallback: i18n.French, expected: i18n.French},
+		{name: "single language", acceptLanguage: "fr", fallback: i18n.English, expected: i18n.French},
+		{name: "region tag", acceptLanguage: "fr-CA", fallback: i18n.English, expected: i18n.French},
+		{name: "upper case region tag", acceptLanguage: "FR-be", fallback: i18n.English, expected: i18n.French},
+		{name: "highest q-value wins", acceptLanguage: "fr;q=0.5, en;q=0.8", fallback: i18n.French, expected: i18n.English},
+		{name: "implicit q of 1 beats explicit lower q", acceptLanguage: "en;q=0.9, fr", fallback: i18n.English, expected: i18n.French},
+		{name: "first of equal q-values wins", acceptLanguage: "fr, en", fallback: i18n.English, expected: i18n.French},
+		{name: "unsupported languages are skipped", acceptLanguage: "de, ja;q=0.9, fr;q=0.1", fallback: i18n.English, expected: i18n.French},
+		{name: "q=0 rules a language out", acceptLanguage: "fr;q=0", fallback: i18n.English, expected: i18n.English},
+		{name: "q=0 does not beat the fallback", acceptLanguage: "en;q=0, de", fallback: i18n.French, expected: i18n.French},
+		{name: "malformed q-value is ignored", acceptLanguage: "fr;q=high, en;q=0.2", fallback: i18n.French, expected: i18n.English},
+		{name: "nothing supported", acceptLanguage: "de-DE, es;q=0.8", fallback: i18n.English, expected: i18n.English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, i18n.Negotiate(tt.acceptLanguage, tt.fallback))
+		})
+	}
+}
+
+func TestSprintf(t *testing.T) {
+	assert.Equal(t, "Tea 42 not found", i18n.Sprintf(i18n.English, "Tea %s not found", "42"))
+	assert.Equal(t, "Thé 42 introuvable", i18n.Sprintf(i18n.French, "Tea %s not found", "42"))
+
+	// Messages missing from a catalog fall back to English
+	assert.Equal(t, "Something new", i18n.Sprintf(i18n.French, "Something new"))
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+)
+
+// AdminTokenHeader is the request header carrying the admin token
+const AdminTokenHeader = "X-Admin-Token"
+
+// AdminToken returns middleware that rejects requests whose admin token header
+// does not match token
+func AdminToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		got := c.GetHeader(AdminTokenHeader)
+		if token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, models.Error{
+				Code:    "UNAUTHORIZED",
+				Message: i18n.Message(c, "Missing or invalid admin token"),
+			})
+			return
+		}
+		c.Next()
+	}
+}
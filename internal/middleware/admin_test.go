@@ -0,0 +1,62 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		configured     string
+		sent           string
+		expectedStatus int
+	}{
+		{
+			name:           "matching token",
+			configured:     "secret",
+			sent:           "secret",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "wrong token",
+			configured:     "secret",
+			sent:           "guess",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "missing token",
+			configured:     "secret",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "no token configured",
+			configured:     "",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/admin", middleware.AdminToken(tt.configured), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/admin", nil)
+			if tt.sent != "" {
+				req.Header.Set(middleware.AdminTokenHeader, tt.sent)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/clock"
+)
+
+// CacheHeader reports whether a GET response was served from the cache
+const CacheHeader = "X-Cache"
+
+// MaxCachedResponses bounds the number of responses kept at once
+const MaxCachedResponses = 1000
+
+// cacheDependencies maps each cacheable top-level resource to the resources
+// whose writes can change its responses; teapots, teas and brews embed or
+// aggregate each other, so a write to any of them invalidates all three
+var cacheDependencies = map[string][]string{
+	"teapots": {"teapots", "teas", "brews"},
+	"teas":    {"teas", "teapots", "brews"},
+	"brews":   {"brews", "teapots", "teas"},
+	"blends":  {"blends"},
+}
+
+// readOnlyRoutes lists write-method routes that never change stored data
+var readOnlyRoutes = map[string]bool{
+	"/brews/validate": true,
+}
+
+// ResponseCache keeps successful GET responses for a short TTL, keyed by
+// path, query and the Accept and Accept-Language headers. A successful
+// POST, PUT, PATCH or DELETE to a resource drops the cached responses that
+// depend on it; changes made outside HTTP, such as by the brew
+// auto-completer, should be reported through Invalidate.
+type ResponseCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+	// generations counts writes per resource so a GET that raced a write
+	// does not store a stale response
+	generations map[string]uint64
+	ttl         time.Duration
+	clock       clock.Clock
+}
+
+type cachedResponse struct {
+	resource string
+	status   int
+	header   http.Header
+	body     []byte
+	expires  time.Time
+}
+
+// NewResponseCache creates a cache that keeps responses for ttl; a nil clock
+// uses the system clock
+func NewResponseCache(ttl time.Duration, clk clock.Clock) *ResponseCache {
+	if clk == nil {
+		clk = clock.System{}
+	}
+	return &ResponseCache{
+		entries:     make(map[string]cachedResponse),
+		generations: make(map[string]uint64),
+		ttl:         ttl,
+		clock:       clk,
+	}
+}
+
+// Middleware returns middleware that serves cached GET responses and
+// invalidates them on writes
+func (rc *ResponseCache) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		resource := topLevelResource(c.Request.URL.Path)
+
+		switch c.Request.Method {
+		case http.MethodGet:
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			c.Next()
+			// Rejected writes and read-only routes leave the data as it was
+			status := c.Writer.Status()
+			if status >= 200 && status < 300 && !readOnlyRoutes[c.FullPath()] {
+				rc.Invalidate(resource)
+			}
+			return
+		default:
+			c.Next()
+			return
+		}
+
+		if _, cacheable := cacheDependencies[resource]; !cacheable {
+			c.Next()
+			return
+		}
+
+		key := c.Request.URL.RequestURI() + "\n" + c.GetHeader("Accept") + "\n" + c.GetHeader("Accept-Language")
+		entry, generation, hit := rc.lookup(key, resource)
+		if hit {
+			header := c.Writer.Header()
+			for name, values := range entry.header {
+				header[name] = values
+			}
+			header.Set(CacheHeader, "HIT")
+			c.Writer.WriteHeader(entry.status)
+			c.Writer.Write(entry.body)
+			c.Abort()
+			return
+		}
+
+		c.Header(CacheHeader, "MISS")
+		writer := &cacheWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		header := writer.Header()
+		if writer.Status() != http.StatusOK || strings.Contains(header.Get("Cache-Control"), "no-store") {
+			return
+		}
+		rc.store(key, generation, cachedResponse{
+			resource: resource,
+			status:   writer.Status(),
+			header:   header.Clone(),
+			body:     writer.body.Bytes(),
+			expires:  rc.clock.Now().Add(rc.ttl),
+		})
+	}
+}
+
+// lookup returns the live entry for key along with the dependency generation
+// a fresh response for resource would be stored under
+func (rc *ResponseCache) lookup(key, resource string) (cachedResponse, uint64, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[key]
+	if ok && rc.clock.Now().Before(entry.expires) {
+		return entry, 0, true
+	}
+	if ok {
+		delete(rc.entries, key)
+	}
+	return cachedResponse{}, rc.generation(resource), false
+}
+
+func (rc *ResponseCache) store(key string, generation uint64, entry cachedResponse) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	// A write landed while the response was being built
+	if rc.generation(entry.resource) != generation {
+		return
+	}
+
+	if len(rc.entries) >= MaxCachedResponses {
+		now := rc.clock.Now()
+		for k, e := range rc.entries {
+			if !now.Before(e.expires) {
+				delete(rc.entries, k)
+			}
+		}
+		if len(rc.entries) >= MaxCachedResponses {
+			return
+		}
+	}
+	rc.entries[key] = entry
+}
+
+// Invalidate drops every cached response that depends on resource; writes
+// to resources outside the dependency table, such as /admin, drop everything
+func (rc *ResponseCache) Invalidate(resource string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	_, known := cacheDependencies[resource]
+	rc.generations[resource]++
+	if !known {
+		for r := range cacheDependencies {
+			rc.generations[r]++
+		}
+	}
+
+	for key, entry := range rc.entries {
+		if !known || dependsOn(entry.resource, resource) {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// generation sums the write counts of the resources a response depends on;
+// callers must hold the lock
+func (rc *ResponseCache) generation(resource string) uint64 {
+	var total uint64
+	for _, dep := range cacheDependencies[resource] {
+		total += rc.generations[dep]
+	}
+	return total
+}
+
+func dependsOn(resource, written string) bool {
+	for _, dep := range cacheDependencies[resource] {
+		if dep == written {
+			return true
+		}
+	}
+	return false
+}
+
+// topLevelResource returns the first path segment, e.g. "teapots" for /teapots/:id/brews
+func topLevelResource(path string) string {
+	resource, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return resource
+}
+
+// cacheWriter copies the response body so it can be cached
+type cacheWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *cacheWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,166 @@
+package middleware_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/clock"
+	"github.com/api2spec/api2spec-fixture-gin/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCache(t *testing.T) {
+	clk := clock.NewFake(time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC))
+	cache := middleware.NewResponseCache(5*time.Second, clk)
+
+	calls := 0
+	count := func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusOK, gin.H{"calls": calls})
+	}
+
+	router := gin.New()
+	router.Use(cache.Middleware())
+	router.GET("/teapots", count)
+	router.POST("/teapots", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	router.POST("/blends", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	router.GET("/teapots/live", func(c *gin.Context) {
+		calls++
+		c.Header("Cache-Control", "no-store")
+		c.JSON(http.StatusOK, gin.H{"calls": calls})
+	})
+	router.GET("/health", count)
+
+	do := func(method, path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := do(http.MethodGet, "/teapots", "")
+	require.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "MISS", first.Header().Get(middleware.CacheHeader))
+
+	repeat := do(http.MethodGet, "/teapots", "")
+	assert.Equal(t, "HIT", repeat.Header().Get(middleware.CacheHeader))
+	assert.Equal(t, first.Body.String(), repeat.Body.String())
+	assert.Equal(t, first.Header().Get("Content-Type"), repeat.Header().Get("Content-Type"))
+	assert.Equal(t, 1, calls)
+
+	// Query and Accept are part of the key
+	assert.Equal(t, "MISS", do(http.MethodGet, "/teapots?page=2", "").Header().Get(middleware.CacheHeader))
+	assert.Equal(t, "MISS", do(http.MethodGet, "/teapots", "application/x-ndjson").Header().Get(middleware.CacheHeader))
+	assert.Equal(t, 3, calls)
+
+	// A write to an unrelated resource keeps the entry
+	do(http.MethodPost, "/blends", "")
+	assert.Equal(t, "HIT", do(http.MethodGet, "/teapots", "").Header().Get(middleware.CacheHeader))
+
+	// A write to the resource drops it
+	do(http.MethodPost, "/teapots", "")
+	afterWrite := do(http.MethodGet, "/teapots", "")
+	assert.Equal(t, "MISS", afterWrite.Header().Get(middleware.CacheHeader))
+	assert.Equal(t, fmt.Sprintf(`{"calls":%d}`, calls), afterWrite.Body.String())
+
+	// Entries expire after the TTL
+	clk.Advance(6 * time.Second)
+	assert.Equal(t, "MISS", do(http.MethodGet, "/teapots", "").Header().Get(middleware.CacheHeader))
+
+	// no-store responses and routes outside the cached resources are never cached
+	do(http.MethodGet, "/teapots/live", "")
+	assert.Equal(t, "MISS", do(http.MethodGet, "/teapots/live", "").Header().Get(middleware.CacheHeader))
+	do(http.MethodGet, "/health", "")
+	health := do(http.MethodGet, "/health", "")
+	assert.Empty(t, health.Header().Get(middleware.CacheHeader))
+}
+
+func TestResponseCache_SkipsErrors(t *testing.T) {
+	cache := middleware.NewResponseCache(time.Minute, nil)
+
+	calls := 0
+	router := gin.New()
+	router.Use(cache.Middleware())
+	router.GET("/teas/:id", func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusNotFound, gin.H{"code": "NOT_FOUND"})
+	})
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/teas/missing", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "MISS", w.Header().Get(middleware.CacheHeader))
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestResponseCache_KeepsEntriesOnReadsAndFailedWrites(t *testing.T) {
+	cache := middleware.NewResponseCache(time.Minute, nil)
+
+	router := gin.New()
+	router.Use(cache.Middleware())
+	router.GET("/brews", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })
+	router.POST("/brews/validate", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"valid": true}) })
+	router.POST("/brews", func(c *gin.Context) { c.JSON(http.StatusBadRequest, gin.H{"code": "VALIDATION_ERROR"}) })
+	router.PATCH("/brews/:id", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	do(http.MethodGet, "/brews")
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		expected string
+	}{
+		{name: "read-only POST", method: http.MethodPost, path: "/brews/validate", expected: "HIT"},
+		{name: "rejected write", method: http.MethodPost, path: "/brews", expected: "HIT"},
+		{name: "unrouted write", method: http.MethodPut, path: "/brews", expected: "HIT"},
+		{name: "HEAD", method: http.MethodHead, path: "/brews", expected: "HIT"},
+		{name: "successful write", method: http.MethodPatch, path: "/brews/1", expected: "MISS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			do(tt.method, tt.path)
+			assert.Equal(t, tt.expected, do(http.MethodGet, "/brews").Header().Get(middleware.CacheHeader))
+		})
+	}
+}
+
+func TestResponseCache_Invalidate(t *testing.T) {
+	cache := middleware.NewResponseCache(time.Minute, nil)
+
+	router := gin.New()
+	router.Use(cache.Middleware())
+	router.GET("/brews", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })
+	router.GET("/blends", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })
+
+	get := func(path string) string {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Header().Get(middleware.CacheHeader)
+	}
+
+	get("/brews")
+	get("/blends")
+	cache.Invalidate("brews")
+	assert.Equal(t, "MISS", get("/brews"))
+	assert.Equal(t, "HIT", get("/blends"))
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/ring"
+)
+
+// MaxCapturedBodyBytes is the number of body bytes kept per captured request or response
+const MaxCapturedBodyBytes = 4096
+
+// redactedHeaders lists request headers whose values are never captured
+var redactedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"X-Api-Key":           true,
+	AdminTokenHeader:      true,
+}
+
+// RequestCapture records the last few request/response pairs in a ring buffer
+type RequestCapture struct {
+	entries *ring.Buffer[models.CapturedRequest]
+}
+
+// NewRequestCapture creates a capture that keeps the last size request/response pairs
+func NewRequestCapture(size int) *RequestCapture {
+	return &RequestCapture{entries: ring.New[models.CapturedRequest](size)}
+}
+
+// Middleware returns middleware that records every request passing through it
+func (rc *RequestCapture) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		entry := models.CapturedRequest{
+			Timestamp:      time.Now().UTC(),
+			Method:         c.Request.Method,
+			Path:           c.Request.URL.RequestURI(),
+			RequestHeaders: redactHeaders(c.Request.Header),
+		}
+
+		if c.Request.Body != nil {
+			// Read one byte past the limit to tell whether the body was cut
+			// short, then hand the handler what was read followed by the rest
+			body, _ := io.ReadAll(io.LimitReader(c.Request.Body, MaxCapturedBodyBytes+1))
+			c.Request.Body = readCloser{
+				Reader: io.MultiReader(bytes.NewReader(body), c.Request.Body),
+				Closer: c.Request.Body,
+			}
+			entry.RequestBody, entry.RequestBodyTruncated = truncateBody(body)
+		}
+
+		writer := &captureWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		entry.Status = writer.Status()
+		entry.ResponseBody = writer.body.String()
+		entry.ResponseBodyTruncated = writer.truncated
+		rc.entries.Add(entry)
+	}
+}
+
+// Recent returns the captured request/response pairs ordered newest first
+func (rc *RequestCapture) Recent() []models.CapturedRequest {
+	return rc.entries.Newest()
+}
+
+// readCloser pairs a request body's replacement reader with the original Close
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// captureWriter copies the first MaxCapturedBodyBytes of the response body
+type captureWriter struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (w *captureWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *captureWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *captureWriter) capture(data []byte) {
+	room := MaxCapturedBodyBytes - w.body.Len()
+	if len(data) > room {
+		data = data[:room]
+		w.truncated = true
+	}
+	w.body.Write(data)
+}
+
+func redactHeaders(header http.Header) map[string][]string {
+	redacted := make(map[string][]string, len(header))
+	for name, values := range header {
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			values = []string{"[REDACTED]"}
+		}
+		redacted[name] = values
+	}
+	return redacted
+}
+
+func truncateBody(body []byte) (string, bool) {
+	if len(body) > MaxCapturedBodyBytes {
+		return string(body[:MaxCapturedBodyBytes]), true
+	}
+	return string(body), false
+}
@@ -0,0 +1,53 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestCapture(t *testing.T) {
+	capture := middleware.NewRequestCapture(2)
+	router := gin.New()
+	router.Use(capture.Middleware())
+	router.POST("/echo", func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		require.NoError(t, err)
+		c.Header("X-Request-Length", strconv.Itoa(len(body)))
+		c.String(http.StatusOK, strings.Repeat("r", middleware.MaxCapturedBodyBytes+10))
+	})
+
+	for i := 0; i < 3; i++ {
+		body := strings.Repeat("q", middleware.MaxCapturedBodyBytes+i)
+		req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(body))
+		req.Header.Set(middleware.AdminTokenHeader, "secret")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		// The handler still sees and sends the full bodies
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, strconv.Itoa(len(body)), w.Header().Get("X-Request-Length"))
+		assert.Len(t, w.Body.String(), middleware.MaxCapturedBodyBytes+10)
+	}
+
+	recent := capture.Recent()
+	require.Len(t, recent, 2)
+	for _, entry := range recent {
+		assert.Len(t, entry.RequestBody, middleware.MaxCapturedBodyBytes)
+		assert.Len(t, entry.ResponseBody, middleware.MaxCapturedBodyBytes)
+		assert.True(t, entry.ResponseBodyTruncated)
+		assert.Equal(t, []string{"[REDACTED]"}, entry.RequestHeaders[middleware.AdminTokenHeader])
+	}
+	// The first request fit exactly and was evicted; the newest two were cut off
+	assert.True(t, recent[0].RequestBodyTruncated)
+	assert.True(t, recent[1].RequestBodyTruncated)
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
+)
+
+// Locale returns middleware that picks the request's locale from the
+// Accept-Language header, falling back to fallback, stores it under
+// i18n.ContextKey for handlers building messages, and reports it in the
+// Content-Language header
+func Locale(fallback i18n.Locale) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := i18n.Negotiate(c.GetHeader("Accept-Language"), fallback)
+		c.Set(i18n.ContextKey, locale)
+		c.Header("Content-Language", string(locale))
+		c.Next()
+	}
+}
@@ -0,0 +1,72 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
+	"github.com/api2spec/api2spec-fixture-gin/internal/middleware"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocale(t *testing.T) {
+	tests := []struct {
+		name            string
+		fallback        i18n.Locale
+		acceptLanguage  string
+		expectedLocale  string
+		expectedMessage string
+	}{
+		{
+			name:            "English by default",
+			fallback:        i18n.English,
+			expectedLocale:  "en",
+			expectedMessage: "Teapot not found",
+		},
+		{
+			name:            "French from Accept-Language",
+			fallback:        i18n.English,
+			acceptLanguage:  "fr-CA,fr;q=0.9,en;q=0.8",
+			expectedLocale:  "fr",
+			expectedMessage: "Théière introuvable",
+		},
+		{
+			name:            "unsupported language uses the configured locale",
+			fallback:        i18n.French,
+			acceptLanguage:  "de",
+			expectedLocale:  "fr",
+			expectedMessage: "Théière introuvable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(middleware.Locale(tt.fallback))
+			router.GET("/missing", func(c *gin.Context) {
+				c.JSON(http.StatusNotFound, models.Error{
+					Code:    "NOT_FOUND",
+					Message: i18n.Message(c, "Teapot not found"),
+				})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Equal(t, tt.expectedLocale, w.Header().Get("Content-Language"))
+
+			var errResp models.Error
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+			assert.Equal(t, "NOT_FOUND", errResp.Code)
+			assert.Equal(t, tt.expectedMessage, errResp.Message)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// BlendComponent represents one tea's share of a blend
+// @Description Blend component
+type BlendComponent struct {
+	TeaID string  `json:"teaId" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Ratio float64 `json:"ratio" example:"0.6"`
+}
+
+// Blend represents a mix of several teas
+// @Description Blend entity
+type Blend struct {
+	ID         string           `json:"id" example:"550e8400-e29b-41d4-a716-446655440004"`
+	Name       string           `json:"name" example:"Breakfast Blend"`
+	Components []BlendComponent `json:"components"`
+	CreatedAt  time.Time        `json:"createdAt" example:"2025-01-04T12:00:00Z"`
+	UpdatedAt  time.Time        `json:"updatedAt" example:"2025-01-04T12:00:00Z"`
+}
+
+// PrimaryTeaID returns the tea with the largest ratio, the first listed on ties
+func (b Blend) PrimaryTeaID() string {
+	var primary BlendComponent
+	for _, component := range b.Components {
+		if component.Ratio > primary.Ratio {
+			primary = component
+		}
+	}
+	return primary.TeaID
+}
+
+// CreateBlendComponentRequest represents one component in a create blend request
+// @Description Create blend component
+type CreateBlendComponentRequest struct {
+	TeaID string  `json:"teaId" binding:"required,anyuuid" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Ratio float64 `json:"ratio" binding:"required,gt=0,lte=1" example:"0.6"`
+}
+
+// CreateBlendRequest represents the request body for creating a blend
+// @Description Create blend request
+type CreateBlendRequest struct {
+	Name       string                        `json:"name" binding:"required,min=1,max=100" example:"Breakfast Blend"`
+	Components []CreateBlendComponentRequest `json:"components" binding:"required,min=1,max=10,dive"`
+}
+
+// BlendListResponse represents a paginated list of blends
+// @Description Paginated blend list response
+type BlendListResponse struct {
+	Data       []Blend    `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
@@ -15,19 +15,76 @@ const (
 	BrewCold      BrewStatus = "cold"
 )
 
+// BrewStatusMeta describes how a brew status is presented and where it can lead
+// @Description Brew status presentation metadata
+type BrewStatusMeta struct {
+	Status BrewStatus   `json:"status" example:"steeping"`
+	Label  string       `json:"label" example:"Steeping"`
+	Color  string       `json:"color" example:"#2E7D32"`
+	Order  int          `json:"order" example:"2"`
+	Next   []BrewStatus `json:"next"`
+}
+
+// BrewStatusMetaResponse represents the list of brew status metadata
+// @Description Brew status metadata list
+type BrewStatusMetaResponse struct {
+	Data []BrewStatusMeta `json:"data"`
+}
+
 // Brew represents a brewing session
 // @Description Brew session entity
 type Brew struct {
-	ID               string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440002"`
-	TeapotID         string     `json:"teapotId" example:"550e8400-e29b-41d4-a716-446655440000"`
-	TeaID            string     `json:"teaId" example:"550e8400-e29b-41d4-a716-446655440001"`
+	ID               string      `json:"id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	TeapotID         string      `json:"teapotId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TeaID            string      `json:"teaId" example:"550e8400-e29b-41d4-a716-446655440001"`
+	BlendID          *string     `json:"blendId,omitempty" example:"550e8400-e29b-41d4-a716-446655440004"`
+	Status           BrewStatus  `json:"status" example:"steeping"`
+	WaterTempCelsius int         `json:"waterTempCelsius" example:"85"`
+	Notes            *string     `json:"notes,omitempty" example:"Using filtered water"`
+	StartedAt        time.Time   `json:"startedAt" example:"2025-01-04T12:00:00Z"`
+	SteepingAt       *time.Time  `json:"steepingAt,omitempty" example:"2025-01-04T12:01:00Z"`
+	CompletedAt      *time.Time  `json:"completedAt,omitempty" example:"2025-01-04T12:05:00Z"`
+	Pauses           []BrewPause `json:"pauses,omitempty"`
+	CreatedAt        time.Time   `json:"createdAt" example:"2025-01-04T12:00:00Z"`
+	UpdatedAt        time.Time   `json:"updatedAt" example:"2025-01-04T12:00:00Z"`
+}
+
+// BrewPause represents an interval during which a brew was paused
+// @Description Brew pause interval
+type BrewPause struct {
+	PausedAt  time.Time  `json:"pausedAt" example:"2025-01-04T12:02:00Z"`
+	ResumedAt *time.Time `json:"resumedAt,omitempty" example:"2025-01-04T12:03:00Z"`
+}
+
+// BrewProgress represents how far along a brew's steep is
+// @Description Brew steep progress
+type BrewProgress struct {
+	BrewID           string     `json:"brewId" example:"550e8400-e29b-41d4-a716-446655440002"`
 	Status           BrewStatus `json:"status" example:"steeping"`
-	WaterTempCelsius int        `json:"waterTempCelsius" example:"85"`
-	Notes            *string    `json:"notes,omitempty" example:"Using filtered water"`
-	StartedAt        time.Time  `json:"startedAt" example:"2025-01-04T12:00:00Z"`
-	CompletedAt      *time.Time `json:"completedAt,omitempty" example:"2025-01-04T12:05:00Z"`
-	CreatedAt        time.Time  `json:"createdAt" example:"2025-01-04T12:00:00Z"`
-	UpdatedAt        time.Time  `json:"updatedAt" example:"2025-01-04T12:00:00Z"`
+	Percent          int        `json:"percent" example:"50"`
+	ElapsedSeconds   int64      `json:"elapsedSeconds" example:"90"`
+	RemainingSeconds int64      `json:"remainingSeconds" example:"90"`
+}
+
+// BrewDetail represents a single brew with derived timing information
+// @Description Brew session with derived fields
+type BrewDetail struct {
+	Brew
+	ActiveSeconds int64 `json:"activeSeconds" example:"240"`
+}
+
+// BrewGetQuery represents query parameters for getting a single brew
+type BrewGetQuery struct {
+	IncludeSteeps bool `form:"includeSteeps"`
+}
+
+// BrewDetailWithSteeps is a brew detail with its steeps embedded in order;
+// SteepsTruncated is set when only the first steeps are included
+// @Description Brew session with embedded steeps
+type BrewDetailWithSteeps struct {
+	BrewDetail
+	Steeps          []Steep `json:"steeps"`
+	SteepsTruncated bool    `json:"steepsTruncated" example:"false"`
 }
 
 // BrewWithDetails includes the related teapot and tea
@@ -41,8 +98,9 @@ type BrewWithDetails struct {
 // CreateBrewRequest represents the request body for creating a brew
 // @Description Create brew request
 type CreateBrewRequest struct {
-	TeapotID         string  `json:"teapotId" binding:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
-	TeaID            string  `json:"teaId" binding:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440001"`
+	TeapotID         string  `json:"teapotId" binding:"required,anyuuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TeaID            string  `json:"teaId" binding:"required_without=BlendID,omitempty,anyuuid" example:"550e8400-e29b-41d4-a716-446655440001"`
+	BlendID          *string `json:"blendId" binding:"omitempty,anyuuid" example:"550e8400-e29b-41d4-a716-446655440004"`
 	WaterTempCelsius *int    `json:"waterTempCelsius" binding:"omitempty,min=60,max=100" example:"85"`
 	Notes            *string `json:"notes" binding:"omitempty,max=500"`
 }
@@ -59,9 +117,47 @@ type PatchBrewRequest struct {
 // @Description Brew list query parameters
 type BrewQuery struct {
 	PaginationQuery
+	FormatQuery
 	Status   *BrewStatus `form:"status" binding:"omitempty,oneof=preparing steeping ready served cold"`
-	TeapotID *string     `form:"teapotId" binding:"omitempty,uuid"`
-	TeaID    *string     `form:"teaId" binding:"omitempty,uuid"`
+	TeapotID *string     `form:"teapotId" binding:"omitempty,anyuuid"`
+	TeaID    *string     `form:"teaId" binding:"omitempty,anyuuid"`
+}
+
+// TeaBrewsQuery represents query parameters for listing a tea's brews
+type TeaBrewsQuery struct {
+	PaginationQuery
+	Expand string `form:"expand" binding:"omitempty,oneof=teapot"`
+}
+
+// BrewWithTeapot is a brew with its teapot summary embedded; Teapot is null
+// when the teapot has since been deleted
+// @Description Brew session with teapot summary
+type BrewWithTeapot struct {
+	Brew
+	Teapot *TeapotSummary `json:"teapot"`
+}
+
+// BrewWithTeapotListResponse represents a paginated list of brews with teapots embedded
+// @Description Paginated brew list with teapot summaries
+type BrewWithTeapotListResponse struct {
+	Data       []BrewWithTeapot `json:"data"`
+	Pagination Pagination       `json:"pagination"`
+}
+
+// BrewPollQuery represents query parameters for long-polling brew changes
+type BrewPollQuery struct {
+	Since   time.Time `form:"since" binding:"required" time_format:"2006-01-02T15:04:05Z07:00" example:"2025-01-04T12:00:00Z"`
+	Timeout *int      `form:"timeout" binding:"omitempty,min=0" example:"30"`
+	Limit   int       `form:"limit" binding:"omitempty,min=1,max=100" default:"100"`
+}
+
+// BrewPollResponse represents brews changed since a poll cursor; pass Until
+// as the next since to continue
+// @Description Brews created or updated since the poll cursor
+type BrewPollResponse struct {
+	Data    []Brew    `json:"data"`
+	Until   time.Time `json:"until" example:"2025-01-04T12:05:00Z"`
+	HasMore bool      `json:"hasMore" example:"false"`
 }
 
 // BrewListResponse represents a paginated list of brews
@@ -70,3 +166,46 @@ type BrewListResponse struct {
 	Data       []Brew     `json:"data"`
 	Pagination Pagination `json:"pagination"`
 }
+
+// CaffeineEstimate represents the estimated caffeine intake for a brew
+// @Description Caffeine intake estimate for a brew session
+type CaffeineEstimate struct {
+	BrewID            string        `json:"brewId" example:"550e8400-e29b-41d4-a716-446655440002"`
+	TeaID             string        `json:"teaId" example:"550e8400-e29b-41d4-a716-446655440001"`
+	CaffeineLevel     CaffeineLevel `json:"caffeineLevel" example:"high"`
+	SteepCount        int           `json:"steepCount" example:"3"`
+	TotalSteepSeconds int           `json:"totalSteepSeconds" example:"540"`
+	EstimatedMg       float64       `json:"estimatedMg" example:"112.5"`
+	Disclaimer        string        `json:"disclaimer" example:"Rough estimate only; actual caffeine content varies by leaf, quantity and water."`
+}
+
+// BrewValidationIssue represents a single problem found when validating a brew
+// @Description Brew validation issue
+type BrewValidationIssue struct {
+	Code    string `json:"code" example:"TEMP_MISMATCH"`
+	Field   string `json:"field,omitempty" example:"waterTempCelsius"`
+	Message string `json:"message" example:"Water temperature is 15°C away from the tea's recommended 80°C"`
+}
+
+// BrewValidationReport represents the advisory result of validating a brew
+// @Description Brew validation report
+type BrewValidationReport struct {
+	Valid    bool                  `json:"valid" example:"true"`
+	Errors   []BrewValidationIssue `json:"errors"`
+	Warnings []BrewValidationIssue `json:"warnings"`
+}
+
+// MaterialOutcome aggregates brews made in teapots of one material
+// @Description Brew outcomes for a teapot material
+type MaterialOutcome struct {
+	Material      TeapotMaterial `json:"material" example:"clay"`
+	BrewCount     int            `json:"brewCount" example:"12"`
+	RatedSteeps   int            `json:"ratedSteeps" example:"30"`
+	AverageRating *float64       `json:"averageRating" example:"4.2"`
+}
+
+// MaterialOutcomeResponse represents brew outcomes per teapot material
+// @Description Brew outcomes grouped by teapot material
+type MaterialOutcomeResponse struct {
+	Data []MaterialOutcome `json:"data"`
+}
@@ -9,6 +9,20 @@ type PaginationQuery struct {
 	Limit int `form:"limit" binding:"omitempty,min=1,max=100" default:"20"`
 }
 
+// FormatQuery selects the list response format
+// @Description List response format query parameter
+type FormatQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=json ndjson" default:"json"`
+}
+
+// NumericFilter represents an operator-style comparison such as capacityMl[gte]=500
+// @Description Numeric comparison filter
+type NumericFilter struct {
+	Field string  `json:"field" example:"capacityMl"`
+	Op    string  `json:"op" example:"gte" enums:"eq,ne,gt,gte,lt,lte"`
+	Value float64 `json:"value" example:"500"`
+}
+
 // Pagination represents pagination metadata in responses
 // @Description Pagination metadata
 type Pagination struct {
@@ -50,6 +64,51 @@ type HealthResponse struct {
 	Checks    []HealthCheck `json:"checks,omitempty"`
 }
 
+// HealthHistoryEntry represents a recorded readiness check result
+// @Description Recorded readiness check result
+type HealthHistoryEntry struct {
+	Status    string        `json:"status" example:"ok" enums:"ok,degraded,down"`
+	Timestamp time.Time     `json:"timestamp" example:"2025-01-04T12:00:00Z"`
+	Checks    []HealthCheck `json:"checks"`
+}
+
+// HealthHistoryResponse represents a paginated list of readiness results
+// @Description Paginated readiness history response
+type HealthHistoryResponse struct {
+	Data       []HealthHistoryEntry `json:"data"`
+	Pagination Pagination           `json:"pagination"`
+}
+
+// RepairReport represents the result of recomputing denormalized data
+// @Description Repair report
+type RepairReport struct {
+	BrewIndexEntriesFixed       int `json:"brewIndexEntriesFixed" example:"0"`
+	SteepIndexEntriesFixed      int `json:"steepIndexEntriesFixed" example:"0"`
+	ExternalIDIndexEntriesFixed int `json:"externalIdIndexEntriesFixed" example:"1"`
+	SteepsRenumbered            int `json:"steepsRenumbered" example:"2"`
+}
+
+// CapturedRequest represents one recorded request/response pair; bodies are
+// cut off at a fixed size and sensitive headers are redacted
+// @Description Captured request and response
+type CapturedRequest struct {
+	Timestamp             time.Time           `json:"timestamp" example:"2025-01-04T12:00:00Z"`
+	Method                string              `json:"method" example:"POST"`
+	Path                  string              `json:"path" example:"/teas?confirm=true"`
+	Status                int                 `json:"status" example:"201"`
+	RequestHeaders        map[string][]string `json:"requestHeaders"`
+	RequestBody           string              `json:"requestBody" example:"{\"name\":\"Sencha\"}"`
+	RequestBodyTruncated  bool                `json:"requestBodyTruncated" example:"false"`
+	ResponseBody          string              `json:"responseBody" example:"{\"id\":\"550e8400-e29b-41d4-a716-446655440001\"}"`
+	ResponseBodyTruncated bool                `json:"responseBodyTruncated" example:"false"`
+}
+
+// CapturedRequestListResponse represents the captured requests, newest first
+// @Description Recently captured requests
+type CapturedRequestListResponse struct {
+	Data []CapturedRequest `json:"data"`
+}
+
 // TeapotResponse represents the TIF 418 response
 // @Description TIF 418 I'm a teapot response
 type TeapotResponse struct {
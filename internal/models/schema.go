@@ -0,0 +1,32 @@
+package models
+
+// FieldSchema describes one field of a stored entity
+// @Description Entity field description
+type FieldSchema struct {
+	Name      string   `json:"name" example:"steepTempCelsius"`
+	Type      string   `json:"type" example:"integer"`
+	Format    string   `json:"format,omitempty" example:"date-time"`
+	Items     string   `json:"items,omitempty" example:"object"`
+	Nullable  bool     `json:"nullable" example:"false"`
+	ReadOnly  bool     `json:"readOnly" example:"false"`
+	Required  bool     `json:"required" example:"true"`
+	Enum      []string `json:"enum,omitempty"`
+	Minimum   *int     `json:"minimum,omitempty" example:"60"`
+	Maximum   *int     `json:"maximum,omitempty" example:"100"`
+	MinLength *int     `json:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty"`
+}
+
+// EntitySchema describes the fields of a stored entity
+// @Description Entity description
+type EntitySchema struct {
+	Name   string        `json:"name" example:"tea"`
+	Fields []FieldSchema `json:"fields"`
+}
+
+// SchemaResponse describes every stored entity for client tooling
+// @Description Store schema response
+type SchemaResponse struct {
+	Version  string         `json:"version" example:"1.0.0"`
+	Entities []EntitySchema `json:"entities"`
+}
@@ -28,3 +28,20 @@ type SteepListResponse struct {
 	Data       []Steep    `json:"data"`
 	Pagination Pagination `json:"pagination"`
 }
+
+// SteepCountBucket represents how many brews have a given number of steeps;
+// MaxSteeps is null for the overflow bucket
+// @Description Steep count histogram bucket
+type SteepCountBucket struct {
+	Label     string `json:"label" example:"3"`
+	MinSteeps int    `json:"minSteeps" example:"3"`
+	MaxSteeps *int   `json:"maxSteeps" example:"3"`
+	Brews     int    `json:"brews" example:"12"`
+}
+
+// SteepDistribution represents the histogram of steep counts across brews
+// @Description Distribution of steep counts across brews
+type SteepDistribution struct {
+	TotalBrews int                `json:"totalBrews" example:"40"`
+	Buckets    []SteepCountBucket `json:"buckets"`
+}
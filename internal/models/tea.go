@@ -17,6 +17,9 @@ const (
 	TeaRooibos TeaType = "rooibos"
 )
 
+// TeaTypes lists every tea type in declaration order
+var TeaTypes = []TeaType{TeaGreen, TeaBlack, TeaOolong, TeaWhite, TeaPuerh, TeaHerbal, TeaRooibos}
+
 // CaffeineLevel represents caffeine content levels
 // @Description Caffeine level
 // @Enum none,low,medium,high
@@ -80,12 +83,56 @@ type PatchTeaRequest struct {
 	Description      *string        `json:"description" binding:"omitempty,max=1000"`
 }
 
+// IsEmpty reports whether the patch sets no fields
+func (p PatchTeaRequest) IsEmpty() bool {
+	return p == PatchTeaRequest{}
+}
+
+// TeaFilter selects teas for bulk operations
+// @Description Tea filter
+type TeaFilter struct {
+	Type          *TeaType       `json:"type" binding:"omitempty,oneof=green black oolong white puerh herbal rooibos" example:"green"`
+	CaffeineLevel *CaffeineLevel `json:"caffeineLevel" binding:"omitempty,oneof=none low medium high"`
+}
+
+// BulkPatchTeasRequest represents the request body for patching many teas at once
+// @Description Bulk patch teas request
+type BulkPatchTeasRequest struct {
+	Filter TeaFilter       `json:"filter"`
+	Patch  PatchTeaRequest `json:"patch"`
+}
+
+// BulkPatchResult represents the outcome of a bulk patch
+// @Description Bulk patch result
+type BulkPatchResult struct {
+	Updated  int          `json:"updated" example:"2"`
+	IDs      []string     `json:"ids"`
+	Warnings []TeaWarning `json:"warnings,omitempty"`
+}
+
+// PatchTeaResponse represents a patched tea along with any warnings the patch raised
+// @Description Patched tea with warnings
+type PatchTeaResponse struct {
+	Tea
+	Warnings []TeaWarning `json:"warnings,omitempty"`
+}
+
+// TeaWarning flags a patched tea whose new type no longer suits its temperature
+// @Description Tea warning
+type TeaWarning struct {
+	ID      string `json:"id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Message string `json:"message" example:"Steep temperature 95°C is outside the 70-85°C range for green tea"`
+}
+
 // TeaQuery represents query parameters for listing teas
 // @Description Tea list query parameters
 type TeaQuery struct {
 	PaginationQuery
-	Type          *TeaType       `form:"type" binding:"omitempty,oneof=green black oolong white puerh herbal rooibos"`
-	CaffeineLevel *CaffeineLevel `form:"caffeineLevel" binding:"omitempty,oneof=none low medium high"`
+	FormatQuery
+	Type           string         `form:"type" example:"green,white"`
+	Types          []TeaType      `form:"-"`
+	CaffeineLevel  *CaffeineLevel `form:"caffeineLevel" binding:"omitempty,oneof=none low medium high"`
+	HasDescription *bool          `form:"hasDescription"`
 }
 
 // TeaListResponse represents a paginated list of teas
@@ -94,3 +141,23 @@ type TeaListResponse struct {
 	Data       []Tea      `json:"data"`
 	Pagination Pagination `json:"pagination"`
 }
+
+// BrewingGuideStep represents one steep in a brewing guide
+// @Description Brewing guide step
+type BrewingGuideStep struct {
+	SteepNumber     int `json:"steepNumber" example:"1"`
+	TempCelsius     int `json:"tempCelsius" example:"80"`
+	DurationSeconds int `json:"durationSeconds" example:"60"`
+}
+
+// BrewingGuide represents multi-steep brewing instructions for a tea
+// @Description Multi-steep brewing guide
+type BrewingGuide struct {
+	TeaID             string             `json:"teaId" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Type              TeaType            `json:"type" example:"green"`
+	TempCelsius       int                `json:"tempCelsius" example:"80"`
+	FirstSteepSeconds int                `json:"firstSteepSeconds" example:"60"`
+	IncrementSeconds  int                `json:"incrementSeconds" example:"30"`
+	SteepCount        int                `json:"steepCount" example:"3"`
+	Steps             []BrewingGuideStep `json:"steps"`
+}
@@ -39,10 +39,60 @@ type Teapot struct {
 	CapacityMl  int            `json:"capacityMl" example:"1200"`
 	Style       TeapotStyle    `json:"style" example:"english"`
 	Description *string        `json:"description" example:"A traditional English teapot"`
+	ExternalID  *string        `json:"externalId,omitempty" example:"catalog-1042"`
 	CreatedAt   time.Time      `json:"createdAt" example:"2025-01-04T12:00:00Z"`
 	UpdatedAt   time.Time      `json:"updatedAt" example:"2025-01-04T12:00:00Z"`
 }
 
+// TeapotSummary represents the identifying fields of a teapot
+// @Description Teapot summary
+type TeapotSummary struct {
+	ID       string         `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name     string         `json:"name" example:"Classic English Teapot"`
+	Material TeapotMaterial `json:"material" example:"ceramic"`
+	Style    TeapotStyle    `json:"style" example:"english"`
+}
+
+// TeaTypeShare represents how many of a teapot's brews used one tea type
+// @Description Tea type brew count
+type TeaTypeShare struct {
+	Type  TeaType `json:"type" example:"oolong"`
+	Count int     `json:"count" example:"6"`
+	Share float64 `json:"share" example:"0.75"`
+}
+
+// TeapotProfile summarizes which tea types a teapot is used for
+// @Description Teapot brewing profile
+type TeapotProfile struct {
+	PreferredType TeaType        `json:"preferredType" example:"oolong"`
+	TotalBrews    int            `json:"totalBrews" example:"8"`
+	Distribution  []TeaTypeShare `json:"distribution"`
+}
+
+// TeapotProfileResponse wraps a teapot's profile, which is null when it has no brews
+// @Description Teapot brewing profile response
+type TeapotProfileResponse struct {
+	TeapotID string         `json:"teapotId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Profile  *TeapotProfile `json:"profile"`
+}
+
+// MaterialCapacity aggregates the teapots of one material
+// @Description Teapot capacity for a material
+type MaterialCapacity struct {
+	Material        TeapotMaterial `json:"material" example:"clay"`
+	Count           int            `json:"count" example:"3"`
+	TotalCapacityMl int            `json:"totalCapacityMl" example:"1050"`
+}
+
+// CapacityReport summarizes the combined capacity of all teapots
+// @Description Teapot capacity report
+type CapacityReport struct {
+	TeapotCount       int                `json:"teapotCount" example:"8"`
+	TotalCapacityMl   int                `json:"totalCapacityMl" example:"5400"`
+	AverageCapacityMl float64            `json:"averageCapacityMl" example:"675"`
+	ByMaterial        []MaterialCapacity `json:"byMaterial"`
+}
+
 // CreateTeapotRequest represents the request body for creating a teapot
 // @Description Create teapot request
 type CreateTeapotRequest struct {
@@ -51,6 +101,7 @@ type CreateTeapotRequest struct {
 	CapacityMl  int            `json:"capacityMl" binding:"required,min=1,max=5000" example:"350"`
 	Style       TeapotStyle    `json:"style" binding:"omitempty,oneof=kyusu gaiwan english moroccan turkish yixing" example:"kyusu"`
 	Description *string        `json:"description" binding:"omitempty,max=500"`
+	ExternalID  *string        `json:"externalId" binding:"omitempty,min=1,max=100" example:"catalog-1042"`
 }
 
 // UpdateTeapotRequest represents the request body for PUT (full replacement)
@@ -77,8 +128,12 @@ type PatchTeapotRequest struct {
 // @Description Teapot list query parameters
 type TeapotQuery struct {
 	PaginationQuery
-	Material *TeapotMaterial `form:"material" binding:"omitempty,oneof=ceramic cast-iron glass porcelain clay stainless-steel"`
-	Style    *TeapotStyle    `form:"style" binding:"omitempty,oneof=kyusu gaiwan english moroccan turkish yixing"`
+	FormatQuery
+	Material       *TeapotMaterial `form:"material" binding:"omitempty,oneof=ceramic cast-iron glass porcelain clay stainless-steel"`
+	Style          *TeapotStyle    `form:"style" binding:"omitempty,oneof=kyusu gaiwan english moroccan turkish yixing"`
+	Idle           *bool           `form:"idle"`
+	HasDescription *bool           `form:"hasDescription"`
+	Numeric        []NumericFilter `form:"-"`
 }
 
 // TeapotListResponse represents a paginated list of teapots
@@ -87,3 +142,9 @@ type TeapotListResponse struct {
 	Data       []Teapot   `json:"data"`
 	Pagination Pagination `json:"pagination"`
 }
+
+// DuplicateTeapotRequest represents the optional request body for duplicating a teapot
+// @Description Duplicate teapot request
+type DuplicateTeapotRequest struct {
+	Name *string `json:"name" binding:"omitempty,min=1,max=100" example:"My Kyusu (copy)"`
+}
@@ -0,0 +1,22 @@
+package pagination
+
+// Page returns one page of items, numbered from 1. Pages past the end
+// short-circuit to an empty slice before any offsets are computed, so huge
+// page numbers cost nothing and cannot overflow.
+func Page[T any](items []T, page, limit int) []T {
+	if limit < 1 || page < 1 {
+		return []T{}
+	}
+	total := len(items)
+	lastPage := (total + limit - 1) / limit
+	if page > lastPage {
+		return []T{}
+	}
+
+	start := (page - 1) * limit
+	end := start + limit
+	if end > total {
+		end = total
+	}
+	return items[start:end]
+}
@@ -0,0 +1,35 @@
+package pagination_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/api2spec/api2spec-fixture-gin/internal/pagination"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPage(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name     string
+		items    []int
+		page     int
+		limit    int
+		expected []int
+	}{
+		{name: "first page", items: items, page: 1, limit: 2, expected: []int{1, 2}},
+		{name: "partial last page", items: items, page: 3, limit: 2, expected: []int{5}},
+		{name: "page past the end", items: items, page: 4, limit: 2, expected: []int{}},
+		{name: "page that would overflow the offset", items: items, page: math.MaxInt, limit: 100, expected: []int{}},
+		{name: "zero limit", items: items, page: 1, limit: 0, expected: []int{}},
+		{name: "zero page", items: items, page: 0, limit: 2, expected: []int{}},
+		{name: "nil items", items: nil, page: 1, limit: 20, expected: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, pagination.Page(tt.items, tt.page, tt.limit))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package ring
+
+import "sync"
+
+// Buffer keeps the last few values added to it; it is safe for concurrent use
+type Buffer[T any] struct {
+	mu      sync.RWMutex
+	entries []T
+	next    int
+	size    int
+}
+
+// New creates a buffer that keeps the last size values; sizes below one keep one
+func New[T any](size int) *Buffer[T] {
+	if size < 1 {
+		size = 1
+	}
+	return &Buffer[T]{
+		entries: make([]T, 0, size),
+		size:    size,
+	}
+}
+
+// Add appends a value, overwriting the oldest once the buffer is full
+func (b *Buffer[T]) Add(value T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.entries) < b.size {
+		b.entries = append(b.entries, value)
+		return
+	}
+	b.entries[b.next] = value
+	b.next = (b.next + 1) % b.size
+}
+
+// Newest returns the kept values ordered newest first
+func (b *Buffer[T]) Newest() []T {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	n := len(b.entries)
+	values := make([]T, 0, n)
+	for i := 0; i < n; i++ {
+		// b.next points at the oldest value once the buffer has wrapped
+		idx := (b.next + n - 1 - i) % n
+		values = append(values, b.entries[idx])
+	}
+	return values
+}
@@ -0,0 +1,32 @@
+package ring_test
+
+import (
+	"testing"
+
+	"github.com/api2spec/api2spec-fixture-gin/internal/ring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuffer(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		add      []int
+		expected []int
+	}{
+		{name: "empty", size: 3, add: nil, expected: []int{}},
+		{name: "partly full", size: 3, add: []int{1, 2}, expected: []int{2, 1}},
+		{name: "wrapped", size: 3, add: []int{1, 2, 3, 4, 5}, expected: []int{5, 4, 3}},
+		{name: "size below one keeps one", size: 0, add: []int{1, 2}, expected: []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := ring.New[int](tt.size)
+			for _, v := range tt.add {
+				b.Add(v)
+			}
+			assert.Equal(t, tt.expected, b.Newest())
+		})
+	}
+}
@@ -1,94 +1,148 @@
 package router
 
 import (
+	"context"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/api2spec/api2spec-fixture-gin/internal/clock"
 	"github.com/api2spec/api2spec-fixture-gin/internal/handlers"
+	"github.com/api2spec/api2spec-fixture-gin/internal/i18n"
+	"github.com/api2spec/api2spec-fixture-gin/internal/middleware"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
 	"github.com/api2spec/api2spec-fixture-gin/internal/store"
 )
 
 // Setup creates and configures the Gin router with all routes
-func Setup() *gin.Engine {
-	r := gin.Default()
-
+func Setup(opts Options) *gin.Engine {
 	// Initialize store
 	memStore := store.NewMemoryStore()
 
-	// Initialize handlers
-	teapotHandler := handlers.NewTeapotHandler(memStore)
-	teaHandler := handlers.NewTeaHandler(memStore)
-	brewHandler := handlers.NewBrewHandler(memStore)
-	healthHandler := handlers.NewHealthHandler()
+	return SetupWithOptions(memStore, opts)
+}
 
-	// Health routes
-	r.GET("/health", healthHandler.Health)
-	r.GET("/health/live", healthHandler.Live)
-	r.GET("/health/ready", healthHandler.Ready)
-	r.GET("/brew", healthHandler.Brew)
+// Options configures optional router behavior
+type Options struct {
+	// AdminToken enables the /admin routes, guarded by the X-Admin-Token header
+	AdminToken string
+
+	// CaptureRequests keeps the last N request/response pairs for
+	// GET /admin/recent-requests; 0 disables capture
+	CaptureRequests int
+
+	// BeforeCreate hooks enrich or reject new entities before they are stored;
+	// nil hooks leave the entity unchanged
+	BeforeCreateTeapot handlers.BeforeCreate[models.Teapot]
+	BeforeCreateTea    handlers.BeforeCreate[models.Tea]
+	BeforeCreateBrew   handlers.BeforeCreate[models.Brew]
+
+	// MaxFutureSkew bounds how far in the future brew timestamps may be;
+	// zero or negative uses handlers.DefaultMaxFutureSkew
+	MaxFutureSkew time.Duration
+
+	// RequireBrewTemp rejects new brews without an explicit waterTempCelsius
+	// (400 TEMP_REQUIRED) instead of defaulting to the tea's temperature
+	RequireBrewTemp bool
+
+	// AutoCompleteInterval enables the background timer that moves steeping
+	// brews to ready and ready brews to cold, scanning at this interval;
+	// zero leaves it off
+	AutoCompleteInterval time.Duration
+	// ColdAfter is how long a ready brew waits before going cold; zero uses
+	// handlers.DefaultColdAfter
+	ColdAfter time.Duration
+
+	// Locale selects the message catalog for error messages when the request's
+	// Accept-Language names no supported language; empty or unsupported
+	// values use English
+	Locale string
+
+	// CacheTTL enables the response cache for GET requests, keeping each
+	// response this long unless a write invalidates it; zero disables caching
+	CacheTTL time.Duration
+
+	// Clock supplies the current time to brew handling and the response
+	// cache; nil uses the system clock
+	Clock clock.Clock
+
+	// Context stops background work such as the auto-complete timer once it
+	// is done; nil runs that work for the life of the process
+	Context context.Context
+}
 
-	// Teapot routes
-	teapots := r.Group("/teapots")
-	{
-		teapots.GET("", teapotHandler.List)
-		teapots.POST("", teapotHandler.Create)
-		teapots.GET("/:id", teapotHandler.Get)
-		teapots.PUT("/:id", teapotHandler.Update)
-		teapots.PATCH("/:id", teapotHandler.Patch)
-		teapots.DELETE("/:id", teapotHandler.Delete)
-		teapots.GET("/:id/brews", brewHandler.ListByTeapot)
-	}
+// SetupWithStore creates and configures the Gin router with a provided store (for testing)
+func SetupWithStore(memStore *store.MemoryStore) *gin.Engine {
+	return SetupWithOptions(memStore, Options{})
+}
 
-	// Tea routes
-	teas := r.Group("/teas")
-	{
-		teas.GET("", teaHandler.List)
-		teas.POST("", teaHandler.Create)
-		teas.GET("/:id", teaHandler.Get)
-		teas.PUT("/:id", teaHandler.Update)
-		teas.PATCH("/:id", teaHandler.Patch)
-		teas.DELETE("/:id", teaHandler.Delete)
+// SetupWithOptions creates and configures the Gin router with a provided store and options
+func SetupWithOptions(memStore *store.MemoryStore, opts Options) *gin.Engine {
+	// The request models rely on custom binding tags
+	if err := handlers.RegisterValidations(); err != nil {
+		panic(err)
 	}
 
-	// Brew routes
-	brews := r.Group("/brews")
-	{
-		brews.GET("", brewHandler.List)
-		brews.POST("", brewHandler.Create)
-		brews.GET("/:id", brewHandler.Get)
-		brews.PATCH("/:id", brewHandler.Patch)
-		brews.DELETE("/:id", brewHandler.Delete)
-		brews.GET("/:id/steeps", brewHandler.ListSteeps)
-		brews.POST("/:id/steeps", brewHandler.CreateSteep)
+	r := gin.Default()
+
+	var capture *middleware.RequestCapture
+	if opts.CaptureRequests > 0 {
+		capture = middleware.NewRequestCapture(opts.CaptureRequests)
+		r.Use(capture.Middleware())
 	}
 
-	return r
-}
+	if opts.CacheTTL > 0 {
+		cache := middleware.NewResponseCache(opts.CacheTTL, opts.Clock)
+		// Writes made outside a request, such as by the auto-completer,
+		// reach the cache through the store
+		memStore.SetChangeHook(cache.Invalidate)
+		r.Use(cache.Middleware())
+	}
 
-// SetupWithStore creates and configures the Gin router with a provided store (for testing)
-func SetupWithStore(memStore *store.MemoryStore) *gin.Engine {
-	r := gin.Default()
+	// Handlers build their messages in the locale chosen here
+	locale, ok := i18n.Parse(opts.Locale)
+	if !ok {
+		locale = i18n.Default
+	}
+	r.Use(middleware.Locale(locale))
 
 	// Initialize handlers
-	teapotHandler := handlers.NewTeapotHandler(memStore)
-	teaHandler := handlers.NewTeaHandler(memStore)
-	brewHandler := handlers.NewBrewHandler(memStore)
+	teapotHandler := handlers.NewTeapotHandlerWithHook(memStore, opts.BeforeCreateTeapot)
+	teaHandler := handlers.NewTeaHandlerWithHook(memStore, opts.BeforeCreateTea)
+	brewHandler := handlers.NewBrewHandlerWithOptions(memStore, handlers.BrewOptions{
+		BeforeCreate:  opts.BeforeCreateBrew,
+		MaxFutureSkew: opts.MaxFutureSkew,
+		RequireTemp:   opts.RequireBrewTemp,
+		Clock:         opts.Clock,
+	})
+	blendHandler := handlers.NewBlendHandler(memStore)
 	healthHandler := handlers.NewHealthHandler()
+	specHandler := handlers.NewSpecHandler()
 
 	// Health routes
 	r.GET("/health", healthHandler.Health)
 	r.GET("/health/live", healthHandler.Live)
 	r.GET("/health/ready", healthHandler.Ready)
+	r.GET("/health/history", healthHandler.History)
 	r.GET("/brew", healthHandler.Brew)
 
+	// API specification
+	r.GET("/openapi.json", specHandler.OpenAPI)
+	r.GET("/schema", specHandler.Schema)
+
 	// Teapot routes
 	teapots := r.Group("/teapots")
 	{
 		teapots.GET("", teapotHandler.List)
 		teapots.POST("", teapotHandler.Create)
+		teapots.GET("/by-external/:externalId", teapotHandler.GetByExternalID)
+		teapots.GET("/capacity-report", teapotHandler.CapacityReport)
 		teapots.GET("/:id", teapotHandler.Get)
 		teapots.PUT("/:id", teapotHandler.Update)
 		teapots.PATCH("/:id", teapotHandler.Patch)
 		teapots.DELETE("/:id", teapotHandler.Delete)
 		teapots.GET("/:id/brews", brewHandler.ListByTeapot)
+		teapots.GET("/:id/profile", teapotHandler.Profile)
+		teapots.POST("/:id/duplicate", teapotHandler.Duplicate)
 	}
 
 	// Tea routes
@@ -96,10 +150,21 @@ func SetupWithStore(memStore *store.MemoryStore) *gin.Engine {
 	{
 		teas.GET("", teaHandler.List)
 		teas.POST("", teaHandler.Create)
+		teas.POST("/bulk-patch", teaHandler.BulkPatch)
 		teas.GET("/:id", teaHandler.Get)
 		teas.PUT("/:id", teaHandler.Update)
 		teas.PATCH("/:id", teaHandler.Patch)
 		teas.DELETE("/:id", teaHandler.Delete)
+		teas.GET("/:id/brews", brewHandler.ListByTea)
+		teas.GET("/:id/brewing-guide", teaHandler.BrewingGuide)
+	}
+
+	// Blend routes
+	blends := r.Group("/blends")
+	{
+		blends.GET("", blendHandler.List)
+		blends.POST("", blendHandler.Create)
+		blends.GET("/:id", blendHandler.Get)
 	}
 
 	// Brew routes
@@ -107,11 +172,47 @@ func SetupWithStore(memStore *store.MemoryStore) *gin.Engine {
 	{
 		brews.GET("", brewHandler.List)
 		brews.POST("", brewHandler.Create)
+		brews.POST("/validate", brewHandler.Validate)
+		brews.GET("/status-meta", brewHandler.StatusMeta)
+		brews.GET("/by-material", brewHandler.ByMaterial)
+		brews.GET("/poll", brewHandler.Poll)
+		brews.GET("/steep-distribution", brewHandler.SteepDistribution)
 		brews.GET("/:id", brewHandler.Get)
 		brews.PATCH("/:id", brewHandler.Patch)
 		brews.DELETE("/:id", brewHandler.Delete)
 		brews.GET("/:id/steeps", brewHandler.ListSteeps)
 		brews.POST("/:id/steeps", brewHandler.CreateSteep)
+		brews.GET("/:id/caffeine-estimate", brewHandler.CaffeineEstimate)
+		brews.POST("/:id/pause", brewHandler.Pause)
+		brews.POST("/:id/resume", brewHandler.Resume)
+		brews.GET("/:id/progress", brewHandler.Progress)
+	}
+
+	// Brew auto-complete timer (off unless an interval is configured)
+	if opts.AutoCompleteInterval > 0 {
+		autoCompleter := handlers.NewBrewAutoCompleter(memStore, handlers.AutoCompleteOptions{
+			Interval:  opts.AutoCompleteInterval,
+			ColdAfter: opts.ColdAfter,
+			Clock:     opts.Clock,
+		})
+		ctx := opts.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		go autoCompleter.Run(ctx)
+	}
+
+	// Admin routes (only when an admin token is configured)
+	if opts.AdminToken != "" {
+		adminHandler := handlers.NewAdminHandlerWithCapture(memStore, capture)
+
+		admin := r.Group("/admin", middleware.AdminToken(opts.AdminToken))
+		{
+			admin.POST("/repair", adminHandler.Repair)
+			if capture != nil {
+				admin.GET("/recent-requests", adminHandler.RecentRequests)
+			}
+		}
 	}
 
 	return r
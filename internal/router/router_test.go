@@ -0,0 +1,502 @@
+package router_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/api2spec/api2spec-fixture-gin/internal/clock"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/router"
+	"github.com/api2spec/api2spec-fixture-gin/internal/spec"
+	"github.com/api2spec/api2spec-fixture-gin/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestRoutesMatchSpec(t *testing.T) {
+	r := router.SetupWithOptions(store.NewMemoryStore(), router.Options{AdminToken: "secret", CaptureRequests: 10})
+
+	paths, _ := spec.Document()["paths"].(map[string]map[string]any)
+
+	registered := make(map[string]bool)
+	for _, route := range r.Routes() {
+		key := route.Method + " " + route.Path
+		registered[key] = true
+
+		operations := paths[spec.OpenAPIPath(route.Path)]
+		assert.Contains(t, operations, strings.ToLower(route.Method), "route %s missing from spec", key)
+	}
+
+	for _, op := range spec.Operations {
+		key := op.Method + " " + op.Path
+		assert.True(t, registered[key], "spec operation %s is not routed", key)
+	}
+}
+
+func TestSetupWithOptions_BeforeCreateHooks(t *testing.T) {
+	opts := router.Options{
+		// Herbal teas default to no caffeine when the client omits the level
+		BeforeCreateTea: func(tea models.Tea) (models.Tea, error) {
+			if tea.Type == models.TeaHerbal && tea.CaffeineLevel == "" {
+				tea.CaffeineLevel = models.CaffeineNone
+			}
+			return tea, nil
+		},
+		// Glass teapots above one litre are rejected
+		BeforeCreateTeapot: func(teapot models.Teapot) (models.Teapot, error) {
+			if teapot.Material == models.MaterialGlass && teapot.CapacityMl > 1000 {
+				return teapot, errors.New("glass teapots may not exceed 1000ml")
+			}
+			return teapot, nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		expectedStatus int
+		check          func(t *testing.T, body []byte)
+	}{
+		{
+			name:           "herbal tea defaults to no caffeine",
+			path:           "/teas",
+			body:           `{"name":"Chamomile","type":"herbal","steepTempCelsius":100,"steepTimeSeconds":300}`,
+			expectedStatus: http.StatusCreated,
+			check: func(t *testing.T, body []byte) {
+				var tea models.Tea
+				require.NoError(t, json.Unmarshal(body, &tea))
+				assert.Equal(t, models.CaffeineNone, tea.CaffeineLevel)
+			},
+		},
+		{
+			name:           "herbal tea keeps the level the client sent",
+			path:           "/teas",
+			body:           `{"name":"Yerba Mate","type":"herbal","caffeineLevel":"high","steepTempCelsius":90,"steepTimeSeconds":300}`,
+			expectedStatus: http.StatusCreated,
+			check: func(t *testing.T, body []byte) {
+				var tea models.Tea
+				require.NoError(t, json.Unmarshal(body, &tea))
+				assert.Equal(t, models.CaffeineHigh, tea.CaffeineLevel)
+			},
+		},
+		{
+			name:           "other teas keep the default level",
+			path:           "/teas",
+			body:           `{"name":"Assam","type":"black","steepTempCelsius":95,"steepTimeSeconds":240}`,
+			expectedStatus: http.StatusCreated,
+			check: func(t *testing.T, body []byte) {
+				var tea models.Tea
+				require.NoError(t, json.Unmarshal(body, &tea))
+				assert.Equal(t, models.CaffeineMedium, tea.CaffeineLevel)
+			},
+		},
+		{
+			name:           "hook rejects teapot",
+			path:           "/teapots",
+			body:           `{"name":"Big Glass","material":"glass","capacityMl":1500}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			check: func(t *testing.T, body []byte) {
+				var errResp models.Error
+				require.NoError(t, json.Unmarshal(body, &errResp))
+				assert.Equal(t, "UNPROCESSABLE_ENTITY", errResp.Code)
+				assert.Contains(t, errResp.Message, "1000ml")
+			},
+		},
+		{
+			name:           "hook accepts teapot",
+			path:           "/teapots",
+			body:           `{"name":"Small Glass","material":"glass","capacityMl":600}`,
+			expectedStatus: http.StatusCreated,
+		},
+	}
+
+	s := store.NewMemoryStore()
+	r := router.SetupWithOptions(s, opts)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.check != nil {
+				tt.check(t, w.Body.Bytes())
+			}
+		})
+	}
+
+	// The rejected teapot was never stored
+	teapots, total := s.ListTeapots(models.TeapotQuery{PaginationQuery: models.PaginationQuery{Page: 1, Limit: 20}})
+	assert.Equal(t, 1, total)
+	require.Len(t, teapots, 1)
+	assert.Equal(t, "Small Glass", teapots[0].Name)
+}
+
+func TestListEndpoints_EmptyDataIsArray(t *testing.T) {
+	// A teapot, a tea and a brew that have nothing nested under them
+	s := store.NewMemoryStore()
+	teapotID := uuid.New().String()
+	s.CreateTeapot(models.Teapot{
+		ID:         teapotID,
+		Name:       "Idle Teapot",
+		Material:   models.MaterialCeramic,
+		CapacityMl: 800,
+		Style:      models.StyleEnglish,
+	})
+	teaID := uuid.New().String()
+	s.CreateTea(models.Tea{
+		ID:               teaID,
+		Name:             "Unbrewed Tea",
+		Type:             models.TeaGreen,
+		CaffeineLevel:    models.CaffeineLow,
+		SteepTempCelsius: 80,
+		SteepTimeSeconds: 120,
+	})
+	brewID := uuid.New().String()
+	s.CreateBrew(models.Brew{
+		ID:               brewID,
+		TeapotID:         uuid.New().String(),
+		TeaID:            uuid.New().String(),
+		Status:           models.BrewPreparing,
+		WaterTempCelsius: 80,
+		StartedAt:        time.Now(),
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	})
+	r := router.SetupWithStore(s)
+	empty := router.SetupWithStore(store.NewMemoryStore())
+
+	tests := []struct {
+		name   string
+		router *gin.Engine
+		path   string
+	}{
+		{name: "teapots", router: empty, path: "/teapots"},
+		{name: "teas", router: empty, path: "/teas"},
+		{name: "brews", router: empty, path: "/brews"},
+		{name: "blends", router: empty, path: "/blends"},
+		{name: "health history", router: empty, path: "/health/history"},
+		{name: "filtered teapots", router: r, path: "/teapots?material=glass"},
+		{name: "filtered teas", router: r, path: "/teas?type=black"},
+		{name: "filtered brews", router: r, path: "/brews?status=cold"},
+		{name: "page past the end", router: r, path: "/teapots?page=5"},
+		{name: "brews by teapot", router: r, path: "/teapots/" + teapotID + "/brews"},
+		{name: "brews by tea", router: r, path: "/teas/" + teaID + "/brews"},
+		{name: "brews by tea expanded", router: r, path: "/teas/" + teaID + "/brews?expand=teapot"},
+		{name: "steeps by brew", router: r, path: "/brews/" + brewID + "/steeps"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			tt.router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var response struct {
+				Data json.RawMessage `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "[]", string(response.Data))
+		})
+	}
+}
+
+func TestRecentRequests(t *testing.T) {
+	r := router.SetupWithOptions(store.NewMemoryStore(), router.Options{AdminToken: "secret", CaptureRequests: 5})
+
+	requests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{method: http.MethodPost, path: "/teas", body: `{"name":"Sencha","type":"green","steepTempCelsius":80,"steepTimeSeconds":60}`, status: http.StatusCreated},
+		{method: http.MethodGet, path: "/teapots/" + uuid.New().String(), status: http.StatusNotFound},
+	}
+	for _, rr := range requests {
+		req := httptest.NewRequest(rr.method, rr.path, strings.NewReader(rr.body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer client-secret")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, rr.status, w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/recent-requests", nil)
+	req.Header.Set("X-Admin-Token", "secret")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response models.CapturedRequestListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data, 2)
+
+	// Newest first
+	notFound, created := response.Data[0], response.Data[1]
+	assert.Equal(t, http.MethodGet, notFound.Method)
+	assert.Equal(t, requests[1].path, notFound.Path)
+	assert.Equal(t, http.StatusNotFound, notFound.Status)
+	assert.Contains(t, notFound.ResponseBody, "NOT_FOUND")
+
+	assert.Equal(t, http.MethodPost, created.Method)
+	assert.Equal(t, "/teas", created.Path)
+	assert.Equal(t, http.StatusCreated, created.Status)
+	assert.Equal(t, requests[0].body, created.RequestBody)
+	assert.Contains(t, created.ResponseBody, `"name":"Sencha"`)
+	assert.Equal(t, []string{"[REDACTED]"}, created.RequestHeaders["Authorization"])
+	assert.Equal(t, []string{"application/json"}, created.RequestHeaders["Content-Type"])
+}
+
+func TestRecentRequests_DisabledByDefault(t *testing.T) {
+	r := router.SetupWithOptions(store.NewMemoryStore(), router.Options{AdminToken: "secret"})
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/recent-requests", nil)
+	req.Header.Set("X-Admin-Token", "secret")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestJSONResponses_DeclareCharset(t *testing.T) {
+	r := router.SetupWithStore(store.NewMemoryStore())
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{name: "list", path: "/teapots", expectedStatus: http.StatusOK},
+		{name: "invalid ID", path: "/teapots/not-a-uuid", expectedStatus: http.StatusBadRequest},
+		{name: "not found", path: "/teapots/" + uuid.New().String(), expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+		})
+	}
+}
+
+func TestResponseCache_InvalidatedByCreate(t *testing.T) {
+	r := router.SetupWithOptions(store.NewMemoryStore(), router.Options{CacheTTL: time.Minute})
+
+	list := func() (string, models.TeaListResponse) {
+		req := httptest.NewRequest(http.MethodGet, "/teas", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response models.TeaListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Header().Get("X-Cache"), response
+	}
+
+	cache, response := list()
+	assert.Equal(t, "MISS", cache)
+	assert.Empty(t, response.Data)
+
+	cache, _ = list()
+	assert.Equal(t, "HIT", cache)
+
+	req := httptest.NewRequest(http.MethodPost, "/teas", strings.NewReader(`{"name":"Sencha","type":"green","steepTempCelsius":80,"steepTimeSeconds":60}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	cache, response = list()
+	assert.Equal(t, "MISS", cache)
+	assert.Len(t, response.Data, 1)
+}
+
+func TestResponseCache_InvalidatedByStoreWrites(t *testing.T) {
+	s := store.NewMemoryStore()
+	// A completed brew, whose response does not change with time
+	completedAt := time.Now()
+	brew := models.Brew{
+		ID:               uuid.New().String(),
+		TeapotID:         uuid.New().String(),
+		TeaID:            uuid.New().String(),
+		Status:           models.BrewReady,
+		WaterTempCelsius: 80,
+		StartedAt:        completedAt.Add(-time.Minute),
+		CompletedAt:      &completedAt,
+		CreatedAt:        completedAt,
+		UpdatedAt:        completedAt,
+	}
+	require.NoError(t, s.CreateBrew(brew))
+	r := router.SetupWithOptions(s, router.Options{CacheTTL: time.Minute})
+
+	get := func() (string, models.Brew) {
+		req := httptest.NewRequest(http.MethodGet, "/brews/"+brew.ID, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response models.Brew
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Header().Get("X-Cache"), response
+	}
+
+	get()
+	cache, _ := get()
+	assert.Equal(t, "HIT", cache)
+
+	// A write made outside any request, as the auto-completer does
+	brew.Status = models.BrewCold
+	s.UpdateBrew(brew)
+
+	cache, response := get()
+	assert.Equal(t, "MISS", cache)
+	assert.Equal(t, models.BrewCold, response.Status)
+}
+
+func TestResponseCache_SkipsRunningBrews(t *testing.T) {
+	clk := clock.NewFake(time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC))
+	s := store.NewMemoryStore()
+	steepingAt := clk.Now()
+	brew := models.Brew{
+		ID:               uuid.New().String(),
+		TeapotID:         uuid.New().String(),
+		TeaID:            uuid.New().String(),
+		Status:           models.BrewSteeping,
+		WaterTempCelsius: 80,
+		StartedAt:        steepingAt,
+		SteepingAt:       &steepingAt,
+		CreatedAt:        steepingAt,
+		UpdatedAt:        steepingAt,
+	}
+	require.NoError(t, s.CreateBrew(brew))
+	r := router.SetupWithOptions(s, router.Options{CacheTTL: time.Minute, Clock: clk})
+
+	get := func() (string, models.BrewDetail) {
+		req := httptest.NewRequest(http.MethodGet, "/brews/"+brew.ID, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response models.BrewDetail
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Header().Get("X-Cache"), response
+	}
+
+	_, first := get()
+	assert.Equal(t, int64(0), first.ActiveSeconds)
+
+	clk.Advance(30 * time.Second)
+	cache, second := get()
+	assert.Equal(t, "MISS", cache)
+	assert.Equal(t, int64(30), second.ActiveSeconds)
+}
+
+func TestResponseCache_DisabledByDefault(t *testing.T) {
+	r := router.SetupWithStore(store.NewMemoryStore())
+
+	req := httptest.NewRequest(http.MethodGet, "/teas", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("X-Cache"))
+}
+
+func TestLocale_TranslatesValidationMessage(t *testing.T) {
+	tests := []struct {
+		name            string
+		locale          string
+		acceptLanguage  string
+		expectedMessage string
+	}{
+		{
+			name:            "English",
+			expectedMessage: "steepTempCelsius must be at least 60",
+		},
+		{
+			name:            "French from Accept-Language",
+			acceptLanguage:  "fr",
+			expectedMessage: "steepTempCelsius doit être au moins 60",
+		},
+		{
+			name:            "French from the locale option",
+			locale:          "fr",
+			expectedMessage: "steepTempCelsius doit être au moins 60",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := router.SetupWithOptions(store.NewMemoryStore(), router.Options{Locale: tt.locale})
+
+			req := httptest.NewRequest(http.MethodPost, "/teas", strings.NewReader(`{"name":"Sencha","type":"green","steepTempCelsius":30,"steepTimeSeconds":60}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusBadRequest, w.Code)
+
+			var errResp models.Error
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+			assert.Equal(t, "VALIDATION_ERROR", errResp.Code)
+			assert.Equal(t, tt.expectedMessage, errResp.Message)
+		})
+	}
+}
+
+func TestLocale_TranslatesValidationReport(t *testing.T) {
+	s := store.NewMemoryStore()
+	teapotID := uuid.New().String()
+	require.NoError(t, s.CreateTeapot(models.Teapot{
+		ID:         teapotID,
+		Name:       "Thimble",
+		Material:   models.MaterialCeramic,
+		CapacityMl: 50,
+		Style:      models.StyleEnglish,
+	}))
+	r := router.SetupWithOptions(s, router.Options{})
+
+	body := `{"teapotId":"` + teapotID + `","teaId":"` + uuid.New().String() + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/brews/validate", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "fr")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "fr", w.Header().Get("Content-Language"))
+
+	var report models.BrewValidationReport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	require.Len(t, report.Errors, 1)
+	assert.Equal(t, "NOT_FOUND", report.Errors[0].Code)
+	assert.Equal(t, "Thé introuvable", report.Errors[0].Message)
+	require.Len(t, report.Warnings, 1)
+	assert.Equal(t, "CAPACITY_TOO_SMALL", report.Warnings[0].Code)
+	assert.Equal(t, "La théière ne contient que 50 ml ; au moins 100 ml sont recommandés", report.Warnings[0].Message)
+}
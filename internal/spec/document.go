@@ -0,0 +1,165 @@
+package spec
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Operation describes a single API route for the generated spec
+type Operation struct {
+	Method      string
+	Path        string // Gin-style path, e.g. /teapots/:id
+	OperationID string
+	Tag         string
+	Summary     string
+	PathParams  map[string]Schema // schema per path parameter; unlisted ones are UUIDs
+	Query       any               // struct whose form tags describe query parameters
+	QueryParams map[string]Schema // query parameters the Query struct cannot describe, such as capacityMl[gte]
+	Headers     []string          // required request headers
+	Body        any               // request body model
+	Responses   []Response
+}
+
+// Response describes one documented response of an operation
+type Response struct {
+	Status int
+	Body   any // response body model; nil for an empty body
+}
+
+// Version is the API version reported by the OpenAPI document and the schema endpoint
+const Version = "1.0.0"
+
+var (
+	documentOnce sync.Once
+	document     map[string]any
+)
+
+// Document returns the OpenAPI 3.0 document for all operations
+func Document() map[string]any {
+	documentOnce.Do(func() {
+		document = build(Operations)
+	})
+	return document
+}
+
+// OpenAPIPath converts a Gin path (/teapots/:id) to OpenAPI form (/teapots/{id})
+func OpenAPIPath(ginPath string) string {
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func build(ops []Operation) map[string]any {
+	b := newSchemaBuilder()
+	paths := make(map[string]map[string]any)
+
+	for _, op := range ops {
+		path := OpenAPIPath(op.Path)
+		if paths[path] == nil {
+			paths[path] = make(map[string]any)
+		}
+		paths[path][strings.ToLower(op.Method)] = b.operation(op)
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Api2spec Fixture Gin API",
+			"version": Version,
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": b.components,
+		},
+	}
+}
+
+func (b *schemaBuilder) operation(op Operation) map[string]any {
+	var params []any
+	for _, segment := range strings.Split(op.Path, "/") {
+		if strings.HasPrefix(segment, ":") {
+			name := segment[1:]
+			schema, ok := op.PathParams[name]
+			if !ok {
+				schema = Schema{"type": "string", "format": "uuid"}
+			}
+			params = append(params, map[string]any{
+				"name":     name,
+				"in":       "path",
+				"required": true,
+				"schema":   schema,
+			})
+		}
+	}
+	for _, header := range op.Headers {
+		params = append(params, map[string]any{
+			"name":     header,
+			"in":       "header",
+			"required": true,
+			"schema":   Schema{"type": "string"},
+		})
+	}
+	if op.Query != nil {
+		for _, f := range fields(reflect.TypeOf(op.Query), "form") {
+			s := b.ref(f.field.Type)
+			applyTags(s, f.field)
+			params = append(params, map[string]any{
+				"name":     f.name,
+				"in":       "query",
+				"required": f.required,
+				"schema":   s,
+			})
+		}
+	}
+	names := make([]string, 0, len(op.QueryParams))
+	for name := range op.QueryParams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		params = append(params, map[string]any{
+			"name":     name,
+			"in":       "query",
+			"required": false,
+			"schema":   op.QueryParams[name],
+		})
+	}
+
+	responses := make(map[string]any)
+	for _, r := range op.Responses {
+		resp := map[string]any{"description": http.StatusText(r.Status)}
+		if r.Body != nil {
+			resp["content"] = map[string]any{
+				"application/json": map[string]any{"schema": b.ref(reflect.TypeOf(r.Body))},
+			}
+		}
+		responses[strconv.Itoa(r.Status)] = resp
+	}
+
+	operation := map[string]any{
+		"operationId": op.OperationID,
+		"tags":        []string{op.Tag},
+		"summary":     op.Summary,
+		"responses":   responses,
+	}
+	if len(params) > 0 {
+		operation["parameters"] = params
+	}
+	if op.Body != nil {
+		operation["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": b.ref(reflect.TypeOf(op.Body))},
+			},
+		}
+	}
+	return operation
+}
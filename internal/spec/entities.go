@@ -0,0 +1,100 @@
+package spec
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+)
+
+// entity pairs a stored model with the create request that validates it;
+// constraints come from the request's binding tags
+type entity struct {
+	name   string
+	model  any
+	create any
+}
+
+var entities = []entity{
+	{name: "teapot", model: models.Teapot{}, create: models.CreateTeapotRequest{}},
+	{name: "tea", model: models.Tea{}, create: models.CreateTeaRequest{}},
+	{name: "blend", model: models.Blend{}, create: models.CreateBlendRequest{}},
+	{name: "brew", model: models.Brew{}, create: models.CreateBrewRequest{}},
+	{name: "steep", model: models.Steep{}, create: models.CreateSteepRequest{}},
+}
+
+var (
+	storeSchemaOnce sync.Once
+	storeSchema     models.SchemaResponse
+)
+
+// StoreSchema returns a description of every stored entity and its fields
+func StoreSchema() models.SchemaResponse {
+	storeSchemaOnce.Do(func() {
+		storeSchema = models.SchemaResponse{Version: Version, Entities: make([]models.EntitySchema, 0, len(entities))}
+		for _, e := range entities {
+			storeSchema.Entities = append(storeSchema.Entities, describeEntity(e))
+		}
+	})
+	return storeSchema
+}
+
+func describeEntity(e entity) models.EntitySchema {
+	inputs := make(map[string]field)
+	for _, f := range fields(reflect.TypeOf(e.create), "json") {
+		inputs[f.name] = f
+	}
+
+	described := models.EntitySchema{Name: e.name, Fields: []models.FieldSchema{}}
+	for _, f := range fields(reflect.TypeOf(e.model), "json") {
+		input, writable := inputs[f.name]
+		fs := models.FieldSchema{
+			Name:     f.name,
+			Nullable: f.field.Type.Kind() == reflect.Pointer,
+			ReadOnly: !writable,
+			Required: writable && input.required,
+		}
+
+		s := fieldTypeSchema(f.field.Type)
+		if writable {
+			applyTags(s, input.field)
+		}
+		fs.Type, _ = s["type"].(string)
+		fs.Format, _ = s["format"].(string)
+		if items, ok := s["items"].(Schema); ok {
+			fs.Items, _ = items["type"].(string)
+		}
+		fs.Enum, _ = s["enum"].([]string)
+		fs.Minimum = intKey(s, "minimum")
+		fs.Maximum = intKey(s, "maximum")
+		fs.MinLength = intKey(s, "minLength")
+		fs.MaxLength = intKey(s, "maxLength")
+
+		described.Fields = append(described.Fields, fs)
+	}
+	return described
+}
+
+// fieldTypeSchema returns a flat schema for t without registering components
+func fieldTypeSchema(t reflect.Type) Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return Schema{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Struct || t.Kind() == reflect.Map:
+		return Schema{"type": "object"}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return Schema{"type": "array", "items": fieldTypeSchema(t.Elem())}
+	}
+	return scalarSchema(t)
+}
+
+func intKey(s Schema, key string) *int {
+	if n, ok := s[key].(int); ok {
+		return &n
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package spec
+
+import (
+	"net/http"
+
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+)
+
+// Shorthands for the common responses
+var (
+	badRequest  = Response{Status: http.StatusBadRequest, Body: models.Error{}}
+	notFound    = Response{Status: http.StatusNotFound, Body: models.Error{}}
+	conflict    = Response{Status: http.StatusConflict, Body: models.Error{}}
+	rejected    = Response{Status: http.StatusUnprocessableEntity, Body: models.Error{}}
+	serverError = Response{Status: http.StatusInternalServerError, Body: models.Error{}}
+	noContent   = Response{Status: http.StatusNoContent}
+)
+
+func ok(body any) Response      { return Response{Status: http.StatusOK, Body: body} }
+func created(body any) Response { return Response{Status: http.StatusCreated, Body: body} }
+
+// numericFilters describes the field[op]=value comparison parameters for each field
+func numericFilters(fields ...string) map[string]Schema {
+	params := make(map[string]Schema)
+	for _, field := range fields {
+		for _, op := range []string{"eq", "ne", "gt", "gte", "lt", "lte"} {
+			params[field+"["+op+"]"] = Schema{"type": "number"}
+		}
+	}
+	return params
+}
+
+// Operations lists every route served by the API; keep it in sync with the router
+var Operations = []Operation{
+	// Health
+	{Method: http.MethodGet, Path: "/health", OperationID: "getHealth", Tag: "health", Summary: "Health check",
+		Responses: []Response{ok(models.HealthResponse{})}},
+	{Method: http.MethodGet, Path: "/health/live", OperationID: "getLiveness", Tag: "health", Summary: "Liveness probe",
+		Responses: []Response{ok(map[string]string{})}},
+	{Method: http.MethodGet, Path: "/health/ready", OperationID: "getReadiness", Tag: "health", Summary: "Readiness probe",
+		Responses: []Response{ok(models.HealthResponse{}), {Status: http.StatusServiceUnavailable, Body: models.HealthResponse{}}}},
+	{Method: http.MethodGet, Path: "/health/history", OperationID: "listHealthHistory", Tag: "health", Summary: "Readiness check history",
+		Query: models.PaginationQuery{}, Responses: []Response{ok(models.HealthHistoryResponse{}), badRequest}},
+	{Method: http.MethodGet, Path: "/brew", OperationID: "brewCoffee", Tag: "health", Summary: "TIF 418 signature endpoint",
+		Responses: []Response{{Status: http.StatusTeapot, Body: models.TeapotResponse{}}}},
+	{Method: http.MethodGet, Path: "/openapi.json", OperationID: "getOpenAPI", Tag: "meta", Summary: "OpenAPI specification",
+		Responses: []Response{ok(map[string]any{})}},
+	{Method: http.MethodGet, Path: "/schema", OperationID: "getSchema", Tag: "meta", Summary: "Store schema",
+		Responses: []Response{ok(models.SchemaResponse{})}},
+
+	// Teapots
+	{Method: http.MethodGet, Path: "/teapots", OperationID: "listTeapots", Tag: "teapots", Summary: "List all teapots",
+		Query: models.TeapotQuery{}, QueryParams: numericFilters("capacityMl"), Responses: []Response{ok(models.TeapotListResponse{}), badRequest}},
+	{Method: http.MethodPost, Path: "/teapots", OperationID: "createTeapot", Tag: "teapots", Summary: "Create a teapot",
+		Body: models.CreateTeapotRequest{}, Responses: []Response{ok(models.Teapot{}), created(models.Teapot{}), badRequest, rejected, serverError}},
+	{Method: http.MethodGet, Path: "/teapots/by-external/:externalId", OperationID: "getTeapotByExternalId", Tag: "teapots", Summary: "Get a teapot by external ID",
+		PathParams: map[string]Schema{"externalId": {"type": "string", "minLength": 1, "maxLength": 100}}, Responses: []Response{ok(models.Teapot{}), notFound}},
+	{Method: http.MethodGet, Path: "/teapots/capacity-report", OperationID: "getTeapotCapacityReport", Tag: "teapots", Summary: "Teapot capacity report",
+		Responses: []Response{ok(models.CapacityReport{})}},
+	{Method: http.MethodGet, Path: "/teapots/:id", OperationID: "getTeapot", Tag: "teapots", Summary: "Get a teapot by ID",
+		Responses: []Response{ok(models.Teapot{}), badRequest, notFound}},
+	{Method: http.MethodPut, Path: "/teapots/:id", OperationID: "updateTeapot", Tag: "teapots", Summary: "Update a teapot (full replacement)",
+		Body: models.UpdateTeapotRequest{}, Responses: []Response{ok(models.Teapot{}), badRequest, notFound}},
+	{Method: http.MethodPatch, Path: "/teapots/:id", OperationID: "patchTeapot", Tag: "teapots", Summary: "Partially update a teapot",
+		Body: models.PatchTeapotRequest{}, Responses: []Response{ok(models.Teapot{}), badRequest, notFound}},
+	{Method: http.MethodDelete, Path: "/teapots/:id", OperationID: "deleteTeapot", Tag: "teapots", Summary: "Delete a teapot",
+		Responses: []Response{noContent, badRequest, notFound}},
+	{Method: http.MethodGet, Path: "/teapots/:id/brews", OperationID: "listTeapotBrews", Tag: "teapots", Summary: "List brews by teapot",
+		Query: models.PaginationQuery{}, Responses: []Response{ok(models.BrewListResponse{}), badRequest, notFound}},
+	{Method: http.MethodGet, Path: "/teapots/:id/profile", OperationID: "getTeapotProfile", Tag: "teapots", Summary: "Get a teapot's brewing profile",
+		Responses: []Response{ok(models.TeapotProfileResponse{}), badRequest, notFound}},
+	{Method: http.MethodPost, Path: "/teapots/:id/duplicate", OperationID: "duplicateTeapot", Tag: "teapots", Summary: "Duplicate a teapot",
+		Body: models.DuplicateTeapotRequest{}, Responses: []Response{created(models.Teapot{}), badRequest, notFound, rejected, serverError}},
+
+	// Teas
+	{Method: http.MethodGet, Path: "/teas", OperationID: "listTeas", Tag: "teas", Summary: "List all teas",
+		Query: models.TeaQuery{}, Responses: []Response{ok(models.TeaListResponse{}), badRequest}},
+	{Method: http.MethodPost, Path: "/teas", OperationID: "createTea", Tag: "teas", Summary: "Create a tea",
+		Body: models.CreateTeaRequest{}, Responses: []Response{created(models.Tea{}), badRequest, rejected, serverError}},
+	{Method: http.MethodPost, Path: "/teas/bulk-patch", OperationID: "bulkPatchTeas", Tag: "teas", Summary: "Patch all teas matching a filter",
+		Query: struct {
+			Confirm bool `form:"confirm" binding:"required"`
+			Strict  bool `form:"strict"`
+		}{}, Body: models.BulkPatchTeasRequest{}, Responses: []Response{ok(models.BulkPatchResult{}), badRequest, rejected}},
+	{Method: http.MethodGet, Path: "/teas/:id", OperationID: "getTea", Tag: "teas", Summary: "Get a tea by ID",
+		Responses: []Response{ok(models.Tea{}), badRequest, notFound}},
+	{Method: http.MethodPut, Path: "/teas/:id", OperationID: "updateTea", Tag: "teas", Summary: "Update a tea (full replacement)",
+		Body: models.UpdateTeaRequest{}, Responses: []Response{ok(models.Tea{}), badRequest, notFound}},
+	{Method: http.MethodPatch, Path: "/teas/:id", OperationID: "patchTea", Tag: "teas", Summary: "Partially update a tea",
+		Query: struct {
+			Strict bool `form:"strict"`
+		}{}, Body: models.PatchTeaRequest{}, Responses: []Response{ok(models.PatchTeaResponse{}), badRequest, notFound, rejected}},
+	{Method: http.MethodDelete, Path: "/teas/:id", OperationID: "deleteTea", Tag: "teas", Summary: "Delete a tea",
+		Responses: []Response{noContent, badRequest, notFound}},
+	{Method: http.MethodGet, Path: "/teas/:id/brews", OperationID: "listTeaBrews", Tag: "teas", Summary: "List brews by tea",
+		Query: models.TeaBrewsQuery{}, Responses: []Response{ok(models.BrewWithTeapotListResponse{}), badRequest, notFound}},
+	{Method: http.MethodGet, Path: "/teas/:id/brewing-guide", OperationID: "getTeaBrewingGuide", Tag: "teas", Summary: "Get a brewing guide for a tea",
+		Responses: []Response{ok(models.BrewingGuide{}), badRequest, notFound}},
+
+	// Blends
+	{Method: http.MethodGet, Path: "/blends", OperationID: "listBlends", Tag: "blends", Summary: "List all blends",
+		Query: models.PaginationQuery{}, Responses: []Response{ok(models.BlendListResponse{}), badRequest}},
+	{Method: http.MethodPost, Path: "/blends", OperationID: "createBlend", Tag: "blends", Summary: "Create a blend",
+		Body: models.CreateBlendRequest{}, Responses: []Response{created(models.Blend{}), badRequest, serverError}},
+	{Method: http.MethodGet, Path: "/blends/:id", OperationID: "getBlend", Tag: "blends", Summary: "Get a blend by ID",
+		Responses: []Response{ok(models.Blend{}), badRequest, notFound}},
+
+	// Brews
+	{Method: http.MethodGet, Path: "/brews", OperationID: "listBrews", Tag: "brews", Summary: "List all brews",
+		Query: models.BrewQuery{}, Responses: []Response{ok(models.BrewListResponse{}), badRequest}},
+	{Method: http.MethodPost, Path: "/brews", OperationID: "createBrew", Tag: "brews", Summary: "Create a brew",
+		Body: models.CreateBrewRequest{}, Responses: []Response{created(models.Brew{}), badRequest, rejected, serverError}},
+	{Method: http.MethodPost, Path: "/brews/validate", OperationID: "validateBrew", Tag: "brews", Summary: "Validate a brew before creating it",
+		Body: models.CreateBrewRequest{}, Responses: []Response{ok(models.BrewValidationReport{})}},
+	{Method: http.MethodGet, Path: "/brews/status-meta", OperationID: "listBrewStatusMeta", Tag: "brews", Summary: "Brew status metadata",
+		Responses: []Response{ok(models.BrewStatusMetaResponse{})}},
+	{Method: http.MethodGet, Path: "/brews/by-material", OperationID: "listBrewOutcomesByMaterial", Tag: "brews", Summary: "Brew outcomes by teapot material",
+		Responses: []Response{ok(models.MaterialOutcomeResponse{})}},
+	{Method: http.MethodGet, Path: "/brews/poll", OperationID: "pollBrews", Tag: "brews", Summary: "Long-poll for brew changes",
+		Query: models.BrewPollQuery{}, Responses: []Response{ok(models.BrewPollResponse{}), badRequest}},
+	{Method: http.MethodGet, Path: "/brews/steep-distribution", OperationID: "getSteepDistribution", Tag: "brews", Summary: "Distribution of steep counts",
+		Responses: []Response{ok(models.SteepDistribution{})}},
+	{Method: http.MethodGet, Path: "/brews/:id", OperationID: "getBrew", Tag: "brews", Summary: "Get a brew by ID",
+		Query: models.BrewGetQuery{}, Responses: []Response{ok(models.BrewDetailWithSteeps{}), badRequest, notFound}},
+	{Method: http.MethodPatch, Path: "/brews/:id", OperationID: "patchBrew", Tag: "brews", Summary: "Partially update a brew",
+		Body: models.PatchBrewRequest{}, Responses: []Response{ok(models.Brew{}), badRequest, notFound, conflict, rejected}},
+	{Method: http.MethodDelete, Path: "/brews/:id", OperationID: "deleteBrew", Tag: "brews", Summary: "Delete a brew",
+		Responses: []Response{noContent, badRequest, notFound}},
+	{Method: http.MethodGet, Path: "/brews/:id/steeps", OperationID: "listBrewSteeps", Tag: "brews", Summary: "List steeps for a brew",
+		Query: models.PaginationQuery{}, Responses: []Response{ok(models.SteepListResponse{}), badRequest, notFound}},
+	{Method: http.MethodPost, Path: "/brews/:id/steeps", OperationID: "createBrewSteep", Tag: "brews", Summary: "Create a steep for a brew",
+		Body: models.CreateSteepRequest{}, Responses: []Response{created(models.Steep{}), badRequest, notFound, serverError}},
+	{Method: http.MethodGet, Path: "/brews/:id/caffeine-estimate", OperationID: "getBrewCaffeineEstimate", Tag: "brews", Summary: "Estimate caffeine intake for a brew",
+		Responses: []Response{ok(models.CaffeineEstimate{}), badRequest, notFound}},
+	{Method: http.MethodPost, Path: "/brews/:id/pause", OperationID: "pauseBrew", Tag: "brews", Summary: "Pause a brew",
+		Responses: []Response{ok(models.BrewDetail{}), badRequest, notFound, conflict}},
+	{Method: http.MethodPost, Path: "/brews/:id/resume", OperationID: "resumeBrew", Tag: "brews", Summary: "Resume a paused brew",
+		Responses: []Response{ok(models.BrewDetail{}), badRequest, notFound, conflict}},
+	{Method: http.MethodGet, Path: "/brews/:id/progress", OperationID: "getBrewProgress", Tag: "brews", Summary: "Get brew progress",
+		Responses: []Response{ok(models.BrewProgress{}), badRequest, notFound}},
+
+	// Admin
+	{Method: http.MethodPost, Path: "/admin/repair", OperationID: "repairStore", Tag: "admin", Summary: "Repair denormalized data",
+		Headers: []string{"X-Admin-Token"}, Responses: []Response{ok(models.RepairReport{}), {Status: http.StatusUnauthorized, Body: models.Error{}}}},
+	{Method: http.MethodGet, Path: "/admin/recent-requests", OperationID: "listRecentRequests", Tag: "admin", Summary: "Recently captured requests",
+		Headers: []string{"X-Admin-Token"}, Responses: []Response{ok(models.CapturedRequestListResponse{}), {Status: http.StatusUnauthorized, Body: models.Error{}}}},
+}
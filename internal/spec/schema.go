@@ -0,0 +1,227 @@
+package spec
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+)
+
+// enumValues lists the allowed values of the named string types used in models
+var enumValues = map[reflect.Type][]string{
+	reflect.TypeOf(models.TeapotMaterial("")): {"ceramic", "cast-iron", "glass", "porcelain", "clay", "stainless-steel"},
+	reflect.TypeOf(models.TeapotStyle("")):    {"kyusu", "gaiwan", "english", "moroccan", "turkish", "yixing"},
+	reflect.TypeOf(models.TeaType("")):        {"green", "black", "oolong", "white", "puerh", "herbal", "rooibos"},
+	reflect.TypeOf(models.CaffeineLevel("")):  {"none", "low", "medium", "high"},
+	reflect.TypeOf(models.BrewStatus("")):     {"preparing", "steeping", "ready", "served", "cold"},
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Schema is an OpenAPI schema object
+type Schema map[string]any
+
+// schemaBuilder converts Go types to OpenAPI schemas, collecting named
+// structs as reusable components
+type schemaBuilder struct {
+	components map[string]Schema
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{components: make(map[string]Schema)}
+}
+
+// ref returns a schema for t, registering structs as components
+func (b *schemaBuilder) ref(t reflect.Type) Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return Schema{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Struct:
+		name := t.Name()
+		if _, ok := b.components[name]; !ok {
+			// Reserve the name first so recursive types terminate
+			b.components[name] = Schema{}
+			b.components[name] = b.object(t)
+		}
+		return Schema{"$ref": "#/components/schemas/" + name}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return Schema{"type": "array", "items": b.ref(t.Elem())}
+	case t.Kind() == reflect.Map:
+		return Schema{"type": "object", "additionalProperties": b.ref(t.Elem())}
+	}
+
+	return scalarSchema(t)
+}
+
+// object builds an inline object schema from the struct's json fields
+func (b *schemaBuilder) object(t reflect.Type) Schema {
+	properties := make(map[string]any)
+	var required []string
+
+	for _, f := range fields(t, "json") {
+		s := b.ref(f.field.Type)
+		if f.field.Type.Kind() != reflect.Struct || f.field.Type == timeType {
+			applyTags(s, f.field)
+		}
+		properties[f.name] = s
+		if f.required {
+			required = append(required, f.name)
+		}
+	}
+
+	schema := Schema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// field describes a struct field exposed under a json or form tag
+type field struct {
+	name     string
+	field    reflect.StructField
+	required bool
+}
+
+// fields returns the exported fields of t keyed by the given tag, flattening
+// untagged embedded structs the way encoding/json and gin binding do
+func fields(t reflect.Type, tag string) []field {
+	var out []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tagValue, hasTag := f.Tag.Lookup(tag)
+		if f.Anonymous && !hasTag {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			out = append(out, fields(ft, tag)...)
+			continue
+		}
+		if !f.IsExported() || tagValue == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tagValue, ",")
+		if name == "" {
+			name = f.Name
+		}
+		out = append(out, field{
+			name:     name,
+			field:    f,
+			required: isRequired(f, strings.Contains(opts, "omitempty")),
+		})
+	}
+	return out
+}
+
+// isRequired reports whether a field must be present: explicitly via a
+// binding rule, or implicitly for always-serialized non-pointer fields
+func isRequired(f reflect.StructField, omitEmpty bool) bool {
+	if binding, ok := f.Tag.Lookup("binding"); ok {
+		for _, rule := range strings.Split(binding, ",") {
+			if rule == "required" {
+				return true
+			}
+		}
+		return false
+	}
+	if _, ok := f.Tag.Lookup("form"); ok {
+		return false
+	}
+	return !omitEmpty && f.Type.Kind() != reflect.Pointer
+}
+
+// scalarSchema maps basic kinds to OpenAPI types
+func scalarSchema(t reflect.Type) Schema {
+	s := Schema{}
+	switch t.Kind() {
+	case reflect.Bool:
+		s["type"] = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		s["type"] = "integer"
+	case reflect.Int64, reflect.Uint64:
+		s["type"] = "integer"
+		s["format"] = "int64"
+	case reflect.Float32, reflect.Float64:
+		s["type"] = "number"
+	case reflect.String:
+		s["type"] = "string"
+	default:
+		s["type"] = "object"
+	}
+	if values, ok := enumValues[t]; ok {
+		s["enum"] = values
+	}
+	return s
+}
+
+// applyTags copies binding constraints, enums, defaults and examples onto s
+func applyTags(s Schema, f reflect.StructField) {
+	target := s
+	if items, ok := s["items"].(Schema); ok && strings.Contains(f.Tag.Get("binding"), "dive") {
+		target = items
+	}
+
+	kind := target["type"]
+	for _, rule := range strings.Split(f.Tag.Get("binding"), ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "min", "max":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			switch {
+			case kind == "string" && key == "min":
+				target["minLength"] = n
+			case kind == "string":
+				target["maxLength"] = n
+			case key == "min":
+				target["minimum"] = n
+			default:
+				target["maximum"] = n
+			}
+		case "oneof":
+			target["enum"] = strings.Fields(value)
+		case "uuid", "anyuuid":
+			target["format"] = "uuid"
+		}
+	}
+
+	if enums, ok := f.Tag.Lookup("enums"); ok {
+		target["enum"] = strings.Split(enums, ",")
+	}
+	if def, ok := f.Tag.Lookup("default"); ok {
+		target["default"] = typedValue(kind, def)
+	}
+	if example, ok := f.Tag.Lookup("example"); ok {
+		s["example"] = typedValue(s["type"], example)
+	}
+}
+
+// typedValue converts a tag value to the JSON type of the schema
+func typedValue(kind any, value string) any {
+	switch kind {
+	case "integer":
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	}
+	return value
+}
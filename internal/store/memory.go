@@ -1,12 +1,19 @@
 package store
 
 import (
+	"errors"
+	"math"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/api2spec/api2spec-fixture-gin/internal/pagination"
 )
 
+// ErrIDCollision is returned when creating an entity whose ID is already stored
+var ErrIDCollision = errors.New("ID already exists")
+
 // MemoryStore provides thread-safe in-memory storage for all entities
 type MemoryStore struct {
 	mu      sync.RWMutex
@@ -14,6 +21,18 @@ type MemoryStore struct {
 	teas    map[string]models.Tea
 	brews   map[string]models.Brew
 	steeps  map[string]models.Steep
+	blends  map[string]models.Blend
+
+	// brewsByTeapot indexes brew IDs by the teapot they reference
+	brewsByTeapot map[string]map[string]struct{}
+	// steepsByBrew indexes steep IDs by the brew they belong to
+	steepsByBrew map[string]map[string]struct{}
+	// teapotsByExternalID maps client-supplied external IDs to teapot IDs
+	teapotsByExternalID map[string]string
+	// brewSignal is closed and replaced whenever a brew is created or updated
+	brewSignal chan struct{}
+	// changeHook, when set, is told the resource each write touched
+	changeHook func(resource string)
 }
 
 // NewMemoryStore creates a new in-memory store
@@ -23,17 +42,60 @@ func NewMemoryStore() *MemoryStore {
 		teas:    make(map[string]models.Tea),
 		brews:   make(map[string]models.Brew),
 		steeps:  make(map[string]models.Steep),
+		blends:  make(map[string]models.Blend),
+
+		brewsByTeapot:       make(map[string]map[string]struct{}),
+		steepsByBrew:        make(map[string]map[string]struct{}),
+		teapotsByExternalID: make(map[string]string),
+		brewSignal:          make(chan struct{}),
+	}
+}
+
+// SetChangeHook registers hook to be called with the top-level resource
+// ("teapots", "teas", "brews" or "blends") after every write, whether it
+// came from a request or from background work such as the brew
+// auto-completer. The hook runs under the store lock and must not call back
+// into the store.
+func (s *MemoryStore) SetChangeHook(hook func(resource string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changeHook = hook
+}
+
+// changed reports a write to the change hook; callers must hold the lock
+func (s *MemoryStore) changed(resource string) {
+	if s.changeHook != nil {
+		s.changeHook(resource)
 	}
 }
 
 // ===== Teapot Methods =====
 
+// TeapotNumericFields maps the teapot fields usable in numeric filters to their values
+var TeapotNumericFields = map[string]func(models.Teapot) float64{
+	"capacityMl": func(t models.Teapot) float64 { return float64(t.CapacityMl) },
+}
+
 // ListTeapots returns a paginated and filtered list of teapots
 func (s *MemoryStore) ListTeapots(query models.TeapotQuery) ([]models.Teapot, int) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	var filtered []models.Teapot
+	filtered := s.filterTeapots(query)
+
+	return pagination.Page(filtered, query.Page, query.Limit), len(filtered)
+}
+
+// ListAllTeapots returns every teapot matching the query filters, ignoring pagination
+func (s *MemoryStore) ListAllTeapots(query models.TeapotQuery) []models.Teapot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.filterTeapots(query)
+}
+
+// filterTeapots applies the query filters and ordering; callers must hold the lock
+func (s *MemoryStore) filterTeapots(query models.TeapotQuery) []models.Teapot {
+	filtered := []models.Teapot{}
 	for _, t := range s.teapots {
 		if query.Material != nil && t.Material != *query.Material {
 			continue
@@ -41,6 +103,15 @@ func (s *MemoryStore) ListTeapots(query models.TeapotQuery) ([]models.Teapot, in
 		if query.Style != nil && t.Style != *query.Style {
 			continue
 		}
+		if query.Idle != nil && s.teapotHasBrews(t.ID) == *query.Idle {
+			continue
+		}
+		if query.HasDescription != nil && hasDescription(t.Description) != *query.HasDescription {
+			continue
+		}
+		if !matchNumeric(query.Numeric, TeapotNumericFields, t) {
+			continue
+		}
 		filtered = append(filtered, t)
 	}
 
@@ -49,25 +120,85 @@ func (s *MemoryStore) ListTeapots(query models.TeapotQuery) ([]models.Teapot, in
 		return filtered[i].CreatedAt.After(filtered[j].CreatedAt)
 	})
 
-	total := len(filtered)
-	start := (query.Page - 1) * query.Limit
-	end := start + query.Limit
+	return filtered
+}
+
+// hasDescription reports whether a description is set and non-empty
+func hasDescription(description *string) bool {
+	return description != nil && *description != ""
+}
 
-	if start >= total {
-		return []models.Teapot{}, total
+// TeapotCapacityReport totals teapot capacity overall and per material in a
+// single pass, ordering materials by name
+func (s *MemoryStore) TeapotCapacityReport() models.CapacityReport {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	report := models.CapacityReport{ByMaterial: []models.MaterialCapacity{}}
+	byMaterial := make(map[models.TeapotMaterial]*models.MaterialCapacity)
+	for _, t := range s.teapots {
+		report.TeapotCount++
+		report.TotalCapacityMl += t.CapacityMl
+
+		material, ok := byMaterial[t.Material]
+		if !ok {
+			material = &models.MaterialCapacity{Material: t.Material}
+			byMaterial[t.Material] = material
+		}
+		material.Count++
+		material.TotalCapacityMl += t.CapacityMl
 	}
-	if end > total {
-		end = total
+
+	if report.TeapotCount > 0 {
+		report.AverageCapacityMl = math.Round(float64(report.TotalCapacityMl)/float64(report.TeapotCount)*100) / 100
 	}
+	for _, material := range byMaterial {
+		report.ByMaterial = append(report.ByMaterial, *material)
+	}
+	sort.Slice(report.ByMaterial, func(i, j int) bool {
+		return report.ByMaterial[i].Material < report.ByMaterial[j].Material
+	})
+	return report
+}
 
-	return filtered[start:end], total
+// CreateTeapot adds a new teapot to the store, refusing to overwrite an existing ID
+func (s *MemoryStore) CreateTeapot(t models.Teapot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.teapots[t.ID]; exists {
+		return ErrIDCollision
+	}
+	s.putTeapot(t)
+	return nil
 }
 
-// CreateTeapot adds a new teapot to the store
-func (s *MemoryStore) CreateTeapot(t models.Teapot) {
+// CreateTeapotIfAbsent adds a teapot unless one with the same external ID
+// already exists, in which case that teapot is returned and created is false
+func (s *MemoryStore) CreateTeapotIfAbsent(t models.Teapot) (teapot models.Teapot, created bool, err error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.teapots[t.ID] = t
+	if t.ExternalID != nil {
+		if id, ok := s.teapotsByExternalID[*t.ExternalID]; ok {
+			return s.teapots[id], false, nil
+		}
+	}
+	if _, exists := s.teapots[t.ID]; exists {
+		return models.Teapot{}, false, ErrIDCollision
+	}
+	s.putTeapot(t)
+	return t, true, nil
+}
+
+// GetTeapotByExternalID retrieves a teapot by its client-supplied external ID
+func (s *MemoryStore) GetTeapotByExternalID(externalID string) (models.Teapot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	id, ok := s.teapotsByExternalID[externalID]
+	if !ok {
+		return models.Teapot{}, false
+	}
+	t, ok := s.teapots[id]
+	return t, ok
 }
 
 // GetTeapot retrieves a teapot by ID
@@ -82,20 +213,150 @@ func (s *MemoryStore) GetTeapot(id string) (models.Teapot, bool) {
 func (s *MemoryStore) UpdateTeapot(t models.Teapot) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.teapots[t.ID] = t
+	s.putTeapot(t)
 }
 
 // DeleteTeapot removes a teapot by ID
 func (s *MemoryStore) DeleteTeapot(id string) bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if _, ok := s.teapots[id]; !ok {
+	t, ok := s.teapots[id]
+	if !ok {
 		return false
 	}
+	s.unindexTeapot(t)
 	delete(s.teapots, id)
+	s.changed("teapots")
 	return true
 }
 
+// putTeapot stores a teapot and keeps the external ID index in sync; callers must hold the lock
+func (s *MemoryStore) putTeapot(t models.Teapot) {
+	if old, ok := s.teapots[t.ID]; ok {
+		s.unindexTeapot(old)
+	}
+	s.teapots[t.ID] = t
+	if t.ExternalID != nil {
+		s.teapotsByExternalID[*t.ExternalID] = t.ID
+	}
+	s.changed("teapots")
+}
+
+// unindexTeapot removes a teapot from the external ID index; callers must hold the lock
+func (s *MemoryStore) unindexTeapot(t models.Teapot) {
+	if t.ExternalID != nil && s.teapotsByExternalID[*t.ExternalID] == t.ID {
+		delete(s.teapotsByExternalID, *t.ExternalID)
+	}
+}
+
+// TeapotHasBrews reports whether any brew references the teapot
+func (s *MemoryStore) TeapotHasBrews(id string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.teapotHasBrews(id)
+}
+
+// teaTypeCountsByTeapot counts the brews made in a teapot per tea type
+func (s *MemoryStore) teaTypeCountsByTeapot(teapotID string) map[models.TeaType]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[models.TeaType]int)
+	for brewID := range s.brewsByTeapot[teapotID] {
+		tea, ok := s.teas[s.brews[brewID].TeaID]
+		if !ok {
+			continue
+		}
+		counts[tea.Type]++
+	}
+	return counts
+}
+
+// TeapotProfile aggregates a teapot's brews by tea type, most brewed first
+// (ties by type name); it returns nil when no brew resolves to a tea
+func (s *MemoryStore) TeapotProfile(teapotID string) *models.TeapotProfile {
+	counts := s.teaTypeCountsByTeapot(teapotID)
+
+	total := 0
+	distribution := make([]models.TeaTypeShare, 0, len(counts))
+	for teaType, count := range counts {
+		total += count
+		distribution = append(distribution, models.TeaTypeShare{Type: teaType, Count: count})
+	}
+	if total == 0 {
+		return nil
+	}
+
+	sort.Slice(distribution, func(i, j int) bool {
+		if distribution[i].Count != distribution[j].Count {
+			return distribution[i].Count > distribution[j].Count
+		}
+		return distribution[i].Type < distribution[j].Type
+	})
+	for i := range distribution {
+		distribution[i].Share = float64(distribution[i].Count) / float64(total)
+	}
+
+	return &models.TeapotProfile{
+		PreferredType: distribution[0].Type,
+		TotalBrews:    total,
+		Distribution:  distribution,
+	}
+}
+
+// teapotHasBrews checks the brew index; callers must hold the lock
+func (s *MemoryStore) teapotHasBrews(id string) bool {
+	return len(s.brewsByTeapot[id]) > 0
+}
+
+// matchNumeric reports whether item satisfies every numeric filter
+func matchNumeric[T any](filters []models.NumericFilter, fields map[string]func(T) float64, item T) bool {
+	for _, f := range filters {
+		value := fields[f.Field](item)
+		var ok bool
+		switch f.Op {
+		case "eq":
+			ok = value == f.Value
+		case "ne":
+			ok = value != f.Value
+		case "gt":
+			ok = value > f.Value
+		case "gte":
+			ok = value >= f.Value
+		case "lt":
+			ok = value < f.Value
+		case "lte":
+			ok = value <= f.Value
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// TeapotSummaries resolves the given teapot IDs in one pass; IDs of deleted
+// teapots are absent from the result
+func (s *MemoryStore) TeapotSummaries(ids []string) map[string]models.TeapotSummary {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	summaries := make(map[string]models.TeapotSummary, len(ids))
+	for _, id := range ids {
+		t, ok := s.teapots[id]
+		if !ok {
+			continue
+		}
+		summaries[id] = models.TeapotSummary{
+			ID:       t.ID,
+			Name:     t.Name,
+			Material: t.Material,
+			Style:    t.Style,
+		}
+	}
+	return summaries
+}
+
 // ===== Tea Methods =====
 
 // ListTeas returns a paginated and filtered list of teas
@@ -103,14 +364,39 @@ func (s *MemoryStore) ListTeas(query models.TeaQuery) ([]models.Tea, int) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	var filtered []models.Tea
+	filtered := s.filterTeas(query)
+
+	return pagination.Page(filtered, query.Page, query.Limit), len(filtered)
+}
+
+// ListAllTeas returns every tea matching the query filters, ignoring pagination
+func (s *MemoryStore) ListAllTeas(query models.TeaQuery) []models.Tea {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.filterTeas(query)
+}
+
+// filterTeas applies the query filters and ordering; callers must hold the lock
+func (s *MemoryStore) filterTeas(query models.TeaQuery) []models.Tea {
+	var types map[models.TeaType]bool
+	if len(query.Types) > 0 {
+		types = make(map[models.TeaType]bool, len(query.Types))
+		for _, t := range query.Types {
+			types[t] = true
+		}
+	}
+
+	filtered := []models.Tea{}
 	for _, t := range s.teas {
-		if query.Type != nil && t.Type != *query.Type {
+		if types != nil && !types[t.Type] {
 			continue
 		}
 		if query.CaffeineLevel != nil && t.CaffeineLevel != *query.CaffeineLevel {
 			continue
 		}
+		if query.HasDescription != nil && hasDescription(t.Description) != *query.HasDescription {
+			continue
+		}
 		filtered = append(filtered, t)
 	}
 
@@ -119,25 +405,19 @@ func (s *MemoryStore) ListTeas(query models.TeaQuery) ([]models.Tea, int) {
 		return filtered[i].CreatedAt.After(filtered[j].CreatedAt)
 	})
 
-	total := len(filtered)
-	start := (query.Page - 1) * query.Limit
-	end := start + query.Limit
-
-	if start >= total {
-		return []models.Tea{}, total
-	}
-	if end > total {
-		end = total
-	}
-
-	return filtered[start:end], total
+	return filtered
 }
 
-// CreateTea adds a new tea to the store
-func (s *MemoryStore) CreateTea(t models.Tea) {
+// CreateTea adds a new tea to the store, refusing to overwrite an existing ID
+func (s *MemoryStore) CreateTea(t models.Tea) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	if _, exists := s.teas[t.ID]; exists {
+		return ErrIDCollision
+	}
 	s.teas[t.ID] = t
+	s.changed("teas")
+	return nil
 }
 
 // GetTea retrieves a tea by ID
@@ -153,6 +433,36 @@ func (s *MemoryStore) UpdateTea(t models.Tea) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.teas[t.ID] = t
+	s.changed("teas")
+}
+
+// UpdateTeasWhere applies update to every tea matching the query filters in a
+// single locked pass and returns the IDs of the updated teas. If update
+// returns an error for any tea, nothing is stored and that error is returned.
+func (s *MemoryStore) UpdateTeasWhere(query models.TeaQuery, update func(models.Tea) (models.Tea, error)) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matched := s.filterTeas(query)
+	updated := make([]models.Tea, 0, len(matched))
+	for _, t := range matched {
+		u, err := update(t)
+		if err != nil {
+			return nil, err
+		}
+		u.ID = t.ID
+		updated = append(updated, u)
+	}
+
+	ids := []string{}
+	for _, t := range updated {
+		s.teas[t.ID] = t
+		ids = append(ids, t.ID)
+	}
+	if len(ids) > 0 {
+		s.changed("teas")
+	}
+	return ids, nil
 }
 
 // DeleteTea removes a tea by ID
@@ -163,6 +473,7 @@ func (s *MemoryStore) DeleteTea(id string) bool {
 		return false
 	}
 	delete(s.teas, id)
+	s.changed("teas")
 	return true
 }
 
@@ -173,7 +484,21 @@ func (s *MemoryStore) ListBrews(query models.BrewQuery) ([]models.Brew, int) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	var filtered []models.Brew
+	filtered := s.filterBrews(query)
+
+	return pagination.Page(filtered, query.Page, query.Limit), len(filtered)
+}
+
+// ListAllBrews returns every brew matching the query filters, ignoring pagination
+func (s *MemoryStore) ListAllBrews(query models.BrewQuery) []models.Brew {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.filterBrews(query)
+}
+
+// filterBrews applies the query filters and ordering; callers must hold the lock
+func (s *MemoryStore) filterBrews(query models.BrewQuery) []models.Brew {
+	filtered := []models.Brew{}
 	for _, b := range s.brews {
 		if query.Status != nil && b.Status != *query.Status {
 			continue
@@ -192,18 +517,7 @@ func (s *MemoryStore) ListBrews(query models.BrewQuery) ([]models.Brew, int) {
 		return filtered[i].CreatedAt.After(filtered[j].CreatedAt)
 	})
 
-	total := len(filtered)
-	start := (query.Page - 1) * query.Limit
-	end := start + query.Limit
-
-	if start >= total {
-		return []models.Brew{}, total
-	}
-	if end > total {
-		end = total
-	}
-
-	return filtered[start:end], total
+	return filtered
 }
 
 // ListBrewsByTeapot returns brews filtered by teapot ID with pagination
@@ -223,25 +537,50 @@ func (s *MemoryStore) ListBrewsByTeapot(teapotID string, page, limit int) ([]mod
 		return filtered[i].CreatedAt.After(filtered[j].CreatedAt)
 	})
 
-	total := len(filtered)
-	start := (page - 1) * limit
-	end := start + limit
+	return pagination.Page(filtered, page, limit), len(filtered)
+}
 
-	if start >= total {
-		return []models.Brew{}, total
-	}
-	if end > total {
-		end = total
-	}
+// ListBrewsByTea returns brews filtered by tea ID with pagination
+func (s *MemoryStore) ListBrewsByTea(teaID string, page, limit int) ([]models.Brew, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	filtered := s.filterBrews(models.BrewQuery{TeaID: &teaID})
+
+	return pagination.Page(filtered, page, limit), len(filtered)
+}
+
+// BrewsUpdatedSince returns brews created or updated after since, oldest
+// first, together with a channel that is closed on the next brew create or
+// update. Deleted brews are not reported.
+func (s *MemoryStore) BrewsUpdatedSince(since time.Time) ([]models.Brew, <-chan struct{}) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	return filtered[start:end], total
+	updated := []models.Brew{}
+	for _, b := range s.brews {
+		if b.UpdatedAt.After(since) {
+			updated = append(updated, b)
+		}
+	}
+	sort.Slice(updated, func(i, j int) bool {
+		if !updated[i].UpdatedAt.Equal(updated[j].UpdatedAt) {
+			return updated[i].UpdatedAt.Before(updated[j].UpdatedAt)
+		}
+		return updated[i].ID < updated[j].ID
+	})
+	return updated, s.brewSignal
 }
 
-// CreateBrew adds a new brew to the store
-func (s *MemoryStore) CreateBrew(b models.Brew) {
+// CreateBrew adds a new brew to the store, refusing to overwrite an existing ID
+func (s *MemoryStore) CreateBrew(b models.Brew) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.brews[b.ID] = b
+	if _, exists := s.brews[b.ID]; exists {
+		return ErrIDCollision
+	}
+	s.putBrew(b)
+	return nil
 }
 
 // GetBrew retrieves a brew by ID
@@ -256,51 +595,125 @@ func (s *MemoryStore) GetBrew(id string) (models.Brew, bool) {
 func (s *MemoryStore) UpdateBrew(b models.Brew) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.brews[b.ID] = b
+	s.putBrew(b)
+}
+
+// CompareAndUpdateBrew stores updated only if the stored brew has not changed
+// since current was read (same UpdatedAt); it reports whether it was stored
+func (s *MemoryStore) CompareAndUpdateBrew(current, updated models.Brew) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.brews[current.ID]
+	if !ok || !stored.UpdatedAt.Equal(current.UpdatedAt) {
+		return false
+	}
+	s.putBrew(updated)
+	return true
 }
 
 // DeleteBrew removes a brew by ID
 func (s *MemoryStore) DeleteBrew(id string) bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if _, ok := s.brews[id]; !ok {
+	b, ok := s.brews[id]
+	if !ok {
 		return false
 	}
+	s.unindexBrew(b)
 	delete(s.brews, id)
+	s.changed("brews")
 	return true
 }
 
-// ===== Steep Methods =====
+// putBrew stores a brew and keeps the teapot index in sync; callers must hold the lock
+func (s *MemoryStore) putBrew(b models.Brew) {
+	if old, ok := s.brews[b.ID]; ok {
+		s.unindexBrew(old)
+	}
+	s.brews[b.ID] = b
+	if s.brewsByTeapot[b.TeapotID] == nil {
+		s.brewsByTeapot[b.TeapotID] = make(map[string]struct{})
+	}
+	s.brewsByTeapot[b.TeapotID][b.ID] = struct{}{}
 
-// ListSteepsByBrew returns steeps filtered by brew ID with pagination
-func (s *MemoryStore) ListSteepsByBrew(brewID string, page, limit int) ([]models.Steep, int) {
+	// Wake everyone waiting in BrewsUpdatedSince
+	close(s.brewSignal)
+	s.brewSignal = make(chan struct{})
+	s.changed("brews")
+}
+
+// unindexBrew removes a brew from the teapot index; callers must hold the lock
+func (s *MemoryStore) unindexBrew(b models.Brew) {
+	ids := s.brewsByTeapot[b.TeapotID]
+	delete(ids, b.ID)
+	if len(ids) == 0 {
+		delete(s.brewsByTeapot, b.TeapotID)
+	}
+}
+
+// BrewOutcomesByMaterial joins brews to their teapots and steeps and returns
+// brew counts and average steep rating per teapot material, ordered by
+// material. Brews whose teapot no longer exists are skipped.
+func (s *MemoryStore) BrewOutcomesByMaterial() []models.MaterialOutcome {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	var filtered []models.Steep
+	brewMaterial := make(map[string]models.TeapotMaterial, len(s.brews))
+	outcomes := make(map[models.TeapotMaterial]*models.MaterialOutcome)
+	for _, b := range s.brews {
+		teapot, ok := s.teapots[b.TeapotID]
+		if !ok {
+			continue
+		}
+		brewMaterial[b.ID] = teapot.Material
+		outcome, ok := outcomes[teapot.Material]
+		if !ok {
+			outcome = &models.MaterialOutcome{Material: teapot.Material}
+			outcomes[teapot.Material] = outcome
+		}
+		outcome.BrewCount++
+	}
+
+	ratingSums := make(map[models.TeapotMaterial]int)
 	for _, steep := range s.steeps {
-		if steep.BrewID == brewID {
-			filtered = append(filtered, steep)
+		material, ok := brewMaterial[steep.BrewID]
+		if !ok || steep.Rating == nil {
+			continue
 		}
+		ratingSums[material] += *steep.Rating
+		outcomes[material].RatedSteeps++
 	}
 
-	// Sort by SteepNumber ascending
-	sort.Slice(filtered, func(i, j int) bool {
-		return filtered[i].SteepNumber < filtered[j].SteepNumber
+	result := make([]models.MaterialOutcome, 0, len(outcomes))
+	for material, outcome := range outcomes {
+		if outcome.RatedSteeps > 0 {
+			avg := math.Round(float64(ratingSums[material])/float64(outcome.RatedSteeps)*100) / 100
+			outcome.AverageRating = &avg
+		}
+		result = append(result, *outcome)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Material < result[j].Material
 	})
+	return result
+}
 
-	total := len(filtered)
-	start := (page - 1) * limit
-	end := start + limit
+// ===== Steep Methods =====
 
-	if start >= total {
-		return []models.Steep{}, total
-	}
-	if end > total {
-		end = total
-	}
+// ListSteepsByBrew returns steeps filtered by brew ID with pagination
+func (s *MemoryStore) ListSteepsByBrew(brewID string, page, limit int) ([]models.Steep, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	return filtered[start:end], total
+	filtered := s.steepsOf(brewID)
+	return pagination.Page(filtered, page, limit), len(filtered)
+}
+
+// ListAllSteepsByBrew returns every steep for a brew ordered by steep number
+func (s *MemoryStore) ListAllSteepsByBrew(brewID string) []models.Steep {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.steepsOf(brewID)
 }
 
 // CountSteepsByBrew returns the number of steeps for a brew
@@ -308,20 +721,55 @@ func (s *MemoryStore) CountSteepsByBrew(brewID string) int {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	count := 0
-	for _, steep := range s.steeps {
-		if steep.BrewID == brewID {
-			count++
+	return len(s.steepsByBrew[brewID])
+}
+
+// SteepCountHistogram counts brews by how many steeps they have. Index i
+// holds the brews with exactly i steeps, and the last index (overflowAt)
+// holds every brew with overflowAt or more.
+func (s *MemoryStore) SteepCountHistogram(overflowAt int) []int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	histogram := make([]int, overflowAt+1)
+	for id := range s.brews {
+		count := len(s.steepsByBrew[id])
+		if count > overflowAt {
+			count = overflowAt
 		}
+		histogram[count]++
 	}
-	return count
+	return histogram
 }
 
-// CreateSteep adds a new steep to the store
-func (s *MemoryStore) CreateSteep(steep models.Steep) {
+// steepsOf returns a brew's steeps ordered by steep number; callers must hold the lock
+func (s *MemoryStore) steepsOf(brewID string) []models.Steep {
+	steeps := make([]models.Steep, 0, len(s.steepsByBrew[brewID]))
+	for id := range s.steepsByBrew[brewID] {
+		steeps = append(steeps, s.steeps[id])
+	}
+
+	// Sort by SteepNumber ascending
+	sort.Slice(steeps, func(i, j int) bool {
+		return steeps[i].SteepNumber < steeps[j].SteepNumber
+	})
+	return steeps
+}
+
+// CreateSteep adds a new steep to the store, refusing to overwrite an existing ID
+func (s *MemoryStore) CreateSteep(steep models.Steep) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	if _, exists := s.steeps[steep.ID]; exists {
+		return ErrIDCollision
+	}
 	s.steeps[steep.ID] = steep
+	if s.steepsByBrew[steep.BrewID] == nil {
+		s.steepsByBrew[steep.BrewID] = make(map[string]struct{})
+	}
+	s.steepsByBrew[steep.BrewID][steep.ID] = struct{}{}
+	s.changed("brews")
+	return nil
 }
 
 // GetSteep retrieves a steep by ID
@@ -331,3 +779,201 @@ func (s *MemoryStore) GetSteep(id string) (models.Steep, bool) {
 	steep, ok := s.steeps[id]
 	return steep, ok
 }
+
+// ===== Blend Methods =====
+
+// ListBlends returns a paginated list of blends, newest first
+func (s *MemoryStore) ListBlends(page, limit int) ([]models.Blend, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	blends := make([]models.Blend, 0, len(s.blends))
+	for _, b := range s.blends {
+		blends = append(blends, b)
+	}
+
+	// Sort by CreatedAt descending for consistent ordering
+	sort.Slice(blends, func(i, j int) bool {
+		return blends[i].CreatedAt.After(blends[j].CreatedAt)
+	})
+
+	return pagination.Page(blends, page, limit), len(blends)
+}
+
+// CreateBlend adds a new blend to the store, refusing to overwrite an existing ID
+func (s *MemoryStore) CreateBlend(b models.Blend) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.blends[b.ID]; exists {
+		return ErrIDCollision
+	}
+	s.blends[b.ID] = b
+	s.changed("blends")
+	return nil
+}
+
+// GetBlend retrieves a blend by ID
+func (s *MemoryStore) GetBlend(id string) (models.Blend, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	b, ok := s.blends[id]
+	return b, ok
+}
+
+// ===== Repair Methods =====
+
+// Brew counts per teapot and tea and steep-derived ratings are computed on
+// read rather than stored, so the indexes below are the only denormalized
+// data that can drift.
+
+// RebuildBrewIndex recomputes the brew-by-teapot index from the brews and
+// returns the number of index entries that had to be added or removed
+func (s *MemoryStore) RebuildBrewIndex() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rebuilt := make(map[string]map[string]struct{})
+	for _, b := range s.brews {
+		addToIndex(rebuilt, b.TeapotID, b.ID)
+	}
+
+	fixed := countIndexDiff(s.brewsByTeapot, rebuilt)
+	s.brewsByTeapot = rebuilt
+	if fixed > 0 {
+		s.changed("brews")
+	}
+	return fixed
+}
+
+// RebuildSteepIndex recomputes the steep-by-brew index from the steeps and
+// returns the number of index entries that had to be added or removed
+func (s *MemoryStore) RebuildSteepIndex() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rebuilt := make(map[string]map[string]struct{})
+	for _, steep := range s.steeps {
+		addToIndex(rebuilt, steep.BrewID, steep.ID)
+	}
+
+	fixed := countIndexDiff(s.steepsByBrew, rebuilt)
+	s.steepsByBrew = rebuilt
+	if fixed > 0 {
+		s.changed("brews")
+	}
+	return fixed
+}
+
+// RebuildExternalIDIndex recomputes the external ID index from the teapots
+// and returns the number of entries that had to be added, changed or removed.
+// A valid existing entry is kept; otherwise an external ID shared by several
+// teapots maps to the oldest of them.
+func (s *MemoryStore) RebuildExternalIDIndex() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rebuilt := make(map[string]string)
+	kept := make(map[string]bool)
+	for externalID, id := range s.teapotsByExternalID {
+		if t, ok := s.teapots[id]; ok && t.ExternalID != nil && *t.ExternalID == externalID {
+			rebuilt[externalID] = id
+			kept[externalID] = true
+		}
+	}
+	for _, t := range s.teapots {
+		if t.ExternalID == nil || kept[*t.ExternalID] {
+			continue
+		}
+		id, ok := rebuilt[*t.ExternalID]
+		if !ok || olderTeapot(t, s.teapots[id]) {
+			rebuilt[*t.ExternalID] = t.ID
+		}
+	}
+
+	fixed := 0
+	for externalID, id := range rebuilt {
+		if s.teapotsByExternalID[externalID] != id {
+			fixed++
+		}
+	}
+	for externalID := range s.teapotsByExternalID {
+		if _, ok := rebuilt[externalID]; !ok {
+			fixed++
+		}
+	}
+
+	s.teapotsByExternalID = rebuilt
+	if fixed > 0 {
+		s.changed("teapots")
+	}
+	return fixed
+}
+
+// olderTeapot reports whether a was created before b, breaking ties by ID
+func olderTeapot(a, b models.Teapot) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.ID < b.ID
+}
+
+// addToIndex records id under key in a set-valued index
+func addToIndex(index map[string]map[string]struct{}, key, id string) {
+	if index[key] == nil {
+		index[key] = make(map[string]struct{})
+	}
+	index[key][id] = struct{}{}
+}
+
+// countIndexDiff counts the entries present in only one of two set-valued indexes
+func countIndexDiff(current, rebuilt map[string]map[string]struct{}) int {
+	diff := 0
+	for key, ids := range rebuilt {
+		for id := range ids {
+			if _, ok := current[key][id]; !ok {
+				diff++
+			}
+		}
+	}
+	for key, ids := range current {
+		for id := range ids {
+			if _, ok := rebuilt[key][id]; !ok {
+				diff++
+			}
+		}
+	}
+	return diff
+}
+
+// RenumberSteeps closes gaps and duplicates in steep numbering so each brew's
+// steeps run 1..n in their existing order, returning the number of steeps changed
+func (s *MemoryStore) RenumberSteeps() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byBrew := make(map[string][]models.Steep)
+	for _, steep := range s.steeps {
+		byBrew[steep.BrewID] = append(byBrew[steep.BrewID], steep)
+	}
+
+	fixed := 0
+	for _, steeps := range byBrew {
+		sort.Slice(steeps, func(i, j int) bool {
+			if steeps[i].SteepNumber != steeps[j].SteepNumber {
+				return steeps[i].SteepNumber < steeps[j].SteepNumber
+			}
+			return steeps[i].CreatedAt.Before(steeps[j].CreatedAt)
+		})
+		for i, steep := range steeps {
+			if steep.SteepNumber != i+1 {
+				steep.SteepNumber = i + 1
+				s.steeps[steep.ID] = steep
+				fixed++
+			}
+		}
+	}
+	if fixed > 0 {
+		s.changed("brews")
+	}
+	return fixed
+}
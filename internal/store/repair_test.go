@@ -0,0 +1,97 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/api2spec/api2spec-fixture-gin/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebuildIndexes(t *testing.T) {
+	s := NewMemoryStore()
+	teapotID := uuid.New().String()
+	brewID := uuid.New().String()
+	require.NoError(t, s.CreateBrew(models.Brew{
+		ID:        brewID,
+		TeapotID:  teapotID,
+		TeaID:     uuid.New().String(),
+		Status:    models.BrewSteeping,
+		StartedAt: time.Now(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}))
+	steepID := uuid.New().String()
+	require.NoError(t, s.CreateSteep(models.Steep{
+		ID:              steepID,
+		BrewID:          brewID,
+		SteepNumber:     1,
+		DurationSeconds: 30,
+		CreatedAt:       time.Now(),
+	}))
+
+	tests := []struct {
+		name    string
+		corrupt func()
+		rebuild func() int
+		check   func(t *testing.T)
+	}{
+		{
+			name: "missing brew index entry",
+			corrupt: func() {
+				delete(s.brewsByTeapot, teapotID)
+			},
+			rebuild: s.RebuildBrewIndex,
+			check: func(t *testing.T) {
+				assert.True(t, s.TeapotHasBrews(teapotID))
+			},
+		},
+		{
+			name: "stale brew index entry",
+			corrupt: func() {
+				addToIndex(s.brewsByTeapot, teapotID, uuid.New().String())
+			},
+			rebuild: s.RebuildBrewIndex,
+			check: func(t *testing.T) {
+				assert.Len(t, s.brewsByTeapot[teapotID], 1)
+			},
+		},
+		{
+			name: "missing steep index entry",
+			corrupt: func() {
+				delete(s.steepsByBrew[brewID], steepID)
+			},
+			rebuild: s.RebuildSteepIndex,
+			check: func(t *testing.T) {
+				assert.Equal(t, 1, s.CountSteepsByBrew(brewID))
+			},
+		},
+		{
+			name: "steep indexed under the wrong brew",
+			corrupt: func() {
+				delete(s.steepsByBrew[brewID], steepID)
+				addToIndex(s.steepsByBrew, uuid.New().String(), steepID)
+			},
+			rebuild: s.RebuildSteepIndex,
+			check: func(t *testing.T) {
+				steeps := s.ListAllSteepsByBrew(brewID)
+				require.Len(t, steeps, 1)
+				assert.Equal(t, steepID, steeps[0].ID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.corrupt()
+
+			assert.Positive(t, tt.rebuild())
+			tt.check(t)
+
+			// A second pass has nothing left to fix
+			assert.Zero(t, tt.rebuild())
+		})
+	}
+}